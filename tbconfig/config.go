@@ -53,16 +53,29 @@ const (
 
 type TransformType string
 
-// LoadConfig загружает конфигурацию в любую структуру с тегами config
+// LoadConfig загружает конфигурацию в любую структуру с тегами config.
+// В окружении local перед этим загружается файл .env.
 func LoadConfig(cfg interface{}) error {
-	env := getEnv(ServiceEnvVarName, string(EnvLocal))
-	if env == "local" {
+	if CurrentEnv() == EnvLocal {
 		err := godotenv.Load(".env")
 		if err != nil {
 			return fmt.Errorf("error loading .env file: %w", err)
 		}
 	}
 
+	return LoadEnv(cfg)
+}
+
+// CurrentEnv возвращает окружение сервиса из переменной NODE_ENV (по умолчанию local)
+func CurrentEnv() Env {
+	return Env(getEnv(ServiceEnvVarName, string(EnvLocal)))
+}
+
+// LoadEnv загружает конфигурацию в структуру с тегами config только из
+// переменных окружения, без чтения файла .env
+func LoadEnv(cfg interface{}) error {
+	env := string(CurrentEnv())
+
 	if err := loadConfigIntoStruct(cfg); err != nil {
 		return err
 	}
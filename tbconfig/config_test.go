@@ -103,3 +103,41 @@ func TestLoadConfig(t *testing.T) {
 		t.Errorf("WpUrl mismatch: got %s, want %s", settings.WpUrl, "https://wp.local")
 	}
 }
+
+func TestLoadEnv(t *testing.T) {
+	t.Setenv("NODE_ENV", "production")
+	t.Setenv("LOAD_ENV_NAME", "billing")
+
+	type envSettings struct {
+		Env  Env
+		Name string `config:"env:LOAD_ENV_NAME"`
+		Port int    `config:"env:LOAD_ENV_PORT,default:8080"`
+	}
+
+	settings := &envSettings{}
+	if err := LoadEnv(settings); err != nil {
+		t.Fatalf("LoadEnv failed: %v", err)
+	}
+
+	if settings.Env != EnvProd {
+		t.Errorf("Env mismatch: got %s, want %s", settings.Env, EnvProd)
+	}
+	if settings.Name != "billing" {
+		t.Errorf("Name mismatch: got %s, want %s", settings.Name, "billing")
+	}
+	if settings.Port != 8080 {
+		t.Errorf("Port mismatch: got %d, want %d", settings.Port, 8080)
+	}
+}
+
+func TestCurrentEnv(t *testing.T) {
+	t.Setenv("NODE_ENV", "staging")
+	if env := CurrentEnv(); env != EnvStaging {
+		t.Errorf("CurrentEnv mismatch: got %s, want %s", env, EnvStaging)
+	}
+
+	os.Unsetenv("NODE_ENV")
+	if env := CurrentEnv(); env != EnvLocal {
+		t.Errorf("CurrentEnv mismatch: got %s, want %s", env, EnvLocal)
+	}
+}
@@ -0,0 +1,165 @@
+package tberrors
+
+import (
+	"log/slog"
+	"runtime"
+)
+
+// maxStackDepth максимальное число сохраняемых кадров стека
+const maxStackDepth = 64
+
+// Error ошибка с сообщением, причиной, полями и стеком вызовов
+type Error struct {
+	msg   string
+	cause error
+	attrs []slog.Attr
+	stack []uintptr
+}
+
+// New создает ошибку с сообщением и полями в формате ключ-значение.
+// В ошибке сохраняется стек вызовов в месте создания.
+func New(msg string, args ...interface{}) error {
+	return &Error{
+		msg:   msg,
+		attrs: argsToAttrs(args),
+		stack: callers(),
+	}
+}
+
+// Wrap оборачивает ошибку, добавляя сообщение и поля в формате ключ-значение.
+// Стек вызовов сохраняется, если его нет ни в одной ошибке цепочки.
+// Для nil возвращает nil.
+func Wrap(err error, msg string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Error{
+		msg:   msg,
+		cause: err,
+		attrs: argsToAttrs(args),
+	}
+	if !hasStack(err) {
+		e.stack = callers()
+	}
+	return e
+}
+
+// With добавляет к ошибке поля в формате ключ-значение, не меняя ее сообщения.
+// Для nil возвращает nil.
+func With(err error, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		cause: err,
+		attrs: argsToAttrs(args),
+	}
+}
+
+// Error реализует error
+func (e *Error) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.msg
+	case e.msg == "":
+		return e.cause.Error()
+	default:
+		return e.msg + ": " + e.cause.Error()
+	}
+}
+
+// Unwrap возвращает причину ошибки
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Attrs возвращает поля ошибки из всей цепочки, включая ветви errors.Join.
+// Поля внешних ошибок идут раньше полей их причин.
+func Attrs(err error) []slog.Attr {
+	var attrs []slog.Attr
+	walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok {
+			attrs = append(attrs, e.attrs...)
+		}
+		return true
+	})
+	return attrs
+}
+
+// StackTrace возвращает стек вызовов, сохраненный в цепочке ошибки (nil — стека нет)
+func StackTrace(err error) []runtime.Frame {
+	var stack []uintptr
+	walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok && len(e.stack) > 0 {
+			stack = e.stack
+			return false
+		}
+		return true
+	})
+	if stack == nil {
+		return nil
+	}
+
+	var result []runtime.Frame
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		result = append(result, frame)
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// hasStack сообщает, что в цепочке ошибки уже сохранен стек вызовов
+func hasStack(err error) bool {
+	found := false
+	walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok && len(e.stack) > 0 {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// walk обходит цепочку ошибки в глубину, включая ветви errors.Join,
+// пока fn возвращает true
+func walk(err error, fn func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !fn(err) {
+		return false
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(u.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if !walk(e, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// callers возвращает стек вызовов функции, создавшей ошибку
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	// Пропускаются runtime.Callers, callers и New/Wrap
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// argsToAttrs преобразует пары ключ-значение в атрибуты по правилам slog
+func argsToAttrs(args []interface{}) []slog.Attr {
+	if len(args) == 0 {
+		return nil
+	}
+	return slog.Group("", args...).Value.Group()
+}
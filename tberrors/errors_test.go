@@ -0,0 +1,88 @@
+package tberrors
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestErrorMessage тестирует сообщения ошибок
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "new",
+			err:      New("user not found"),
+			expected: "user not found",
+		},
+		{
+			name:     "wrap",
+			err:      Wrap(io.EOF, "read body"),
+			expected: "read body: EOF",
+		},
+		{
+			name:     "with",
+			err:      With(io.EOF, "attempt", 3),
+			expected: "EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+
+	assert.Nil(t, Wrap(nil, "read body"))
+	assert.Nil(t, With(nil, "attempt", 3))
+}
+
+// TestErrorUnwrap тестирует совместимость с errors.Is
+func TestErrorUnwrap(t *testing.T) {
+	err := With(Wrap(io.EOF, "read body"), "attempt", 3)
+	assert.ErrorIs(t, err, io.EOF)
+
+	var e *Error
+	assert.ErrorAs(t, err, &e)
+}
+
+// TestAttrs тестирует сбор полей по цепочке
+func TestAttrs(t *testing.T) {
+	inner := New("user not found", "user_id", 42)
+	outer := With(Wrap(inner, "load profile", "tenant", "t-1"), "attempt", 3)
+	joined := errors.Join(outer, New("cache miss", "key", "profile:42"))
+
+	attrs := Attrs(joined)
+	keys := make([]string, len(attrs))
+	for i, a := range attrs {
+		keys[i] = a.Key
+	}
+	assert.Equal(t, []string{"attempt", "tenant", "user_id", "key"}, keys)
+	assert.Equal(t, slog.IntValue(42).Int64(), attrs[2].Value.Int64())
+
+	assert.Nil(t, Attrs(io.EOF))
+}
+
+// TestStackTrace тестирует сохранение стека вызовов
+func TestStackTrace(t *testing.T) {
+	assert.Nil(t, StackTrace(io.EOF))
+	assert.Nil(t, StackTrace(With(io.EOF, "attempt", 3)))
+
+	err := Wrap(io.EOF, "read body")
+	frames := StackTrace(err)
+	require.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "TestStackTrace"))
+
+	// Повторное оборачивание сохраняет исходный стек
+	wrapped := func() error { return Wrap(err, "handle request") }()
+	assert.Equal(t, frames[0].Function, StackTrace(wrapped)[0].Function)
+	assert.Nil(t, wrapped.(*Error).stack)
+}
@@ -0,0 +1,222 @@
+package tblogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LevelHandler — http.Handler для просмотра и изменения уровней логирования во время работы.
+//
+//	GET                              — текущий глобальный уровень и уровни компонентов
+//	PUT/POST {"level": "DEBUG"}      — изменить глобальный уровень
+//	PUT/POST {"level": "DEBUG", "component": "kafka", "ttl": "10m"}
+//	                                 — изменить уровень компонента с автоматическим возвратом через 10 минут
+//	DELETE ?component=kafka          — удалить переопределение уровня компонента
+//
+// В ответе поле reverts содержит время запланированного возврата уровней ("*" — глобальный уровень).
+type LevelHandler struct {
+	logger  *Logger
+	mu      sync.Mutex
+	reverts map[string]*levelRevert // ключ — имя компонента, "" — глобальный уровень
+}
+
+// levelRevert отложенный возврат уровня к исходному значению
+type levelRevert struct {
+	timer   *time.Timer
+	expires time.Time
+	restore func()
+}
+
+// levelRequest тело запроса на изменение уровня
+type levelRequest struct {
+	Level     string `json:"level"`
+	Component string `json:"component,omitempty"`
+	TTL       string `json:"ttl,omitempty"`
+}
+
+// levelResponse тело ответа с текущими уровнями
+type levelResponse struct {
+	Level      string               `json:"level"`
+	Components map[string]string    `json:"components"`
+	Reverts    map[string]time.Time `json:"reverts,omitempty"`
+}
+
+// errorResponse тело ответа с ошибкой
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewLevelHandler создает http.Handler для управления уровнями логгера
+func NewLevelHandler(logger *Logger) *LevelHandler {
+	return &LevelHandler{
+		logger:  logger,
+		reverts: make(map[string]*levelRevert),
+	}
+}
+
+// ServeHTTP реализует http.Handler
+func (h *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.logger.levels == nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "logger does not support dynamic levels"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		writeJSON(w, http.StatusOK, h.state())
+	case http.MethodPut, http.MethodPost:
+		var req levelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+			return
+		}
+		if err := h.apply(req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, h.state())
+	case http.MethodDelete:
+		component := normalizeComponent(r.URL.Query().Get("component"))
+		if component == "" {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "component is required"})
+			return
+		}
+		// Отложенные возвраты хранятся под нормализованным именем, как в apply
+		h.mu.Lock()
+		h.cancelRevert(component)
+		h.logger.levels.removeOverride(component)
+		h.mu.Unlock()
+		h.logger.Info("Log level override removed", "target_component", component)
+		writeJSON(w, http.StatusOK, h.state())
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST, DELETE")
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+	}
+}
+
+// apply применяет изменение уровня из запроса
+func (h *LevelHandler) apply(req levelRequest) error {
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		ttl, err = time.ParseDuration(req.TTL)
+		if err != nil {
+			return fmt.Errorf("invalid ttl: %w", err)
+		}
+		if ttl <= 0 {
+			return errors.New("ttl must be positive")
+		}
+	}
+
+	component := normalizeComponent(req.Component)
+	levels := h.logger.levels
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// При повторном изменении с TTL возвращаемся к значению, действовавшему до первого изменения
+	restore := h.cancelRevert(component)
+	if restore == nil {
+		restore = h.restoreFunc(component)
+	}
+
+	if component == "" {
+		levels.global.Set(slog.Level(level))
+	} else {
+		levels.setOverride(component, level)
+	}
+
+	if ttl > 0 {
+		revert := &levelRevert{expires: currentTime().Add(ttl), restore: restore}
+		revert.timer = time.AfterFunc(ttl, func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if h.reverts[component] != revert {
+				return
+			}
+			delete(h.reverts, component)
+			revert.restore()
+			h.logger.Info("Log level reverted", "target_component", component)
+		})
+		h.reverts[component] = revert
+	}
+
+	h.logger.Info("Log level changed",
+		"target_component", component,
+		"new_level", level.String(),
+		"ttl", ttl.String(),
+	)
+	return nil
+}
+
+// restoreFunc запоминает текущий уровень цели и возвращает функцию его восстановления
+func (h *LevelHandler) restoreFunc(component string) func() {
+	levels := h.logger.levels
+	if component == "" {
+		previous := levels.global.Level()
+		return func() { levels.global.Set(previous) }
+	}
+
+	previous, ok := levels.snapshot()[component]
+	return func() {
+		if ok {
+			levels.setOverride(component, previous)
+		} else {
+			levels.removeOverride(component)
+		}
+	}
+}
+
+// cancelRevert отменяет отложенный возврат уровня и возвращает его функцию восстановления.
+// Вызывается под h.mu.
+func (h *LevelHandler) cancelRevert(component string) func() {
+	revert, ok := h.reverts[component]
+	if !ok {
+		return nil
+	}
+	revert.timer.Stop()
+	delete(h.reverts, component)
+	return revert.restore
+}
+
+// state возвращает текущие уровни
+func (h *LevelHandler) state() levelResponse {
+	levels := h.logger.levels
+	resp := levelResponse{
+		Level:      LogLevel(levels.global.Level()).String(),
+		Components: make(map[string]string),
+	}
+	for component, level := range levels.snapshot() {
+		resp.Components[component] = level.String()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for component, revert := range h.reverts {
+		if resp.Reverts == nil {
+			resp.Reverts = make(map[string]time.Time)
+		}
+		key := component
+		if key == "" {
+			key = "*"
+		}
+		resp.Reverts[key] = revert.expires
+	}
+	return resp
+}
+
+// writeJSON записывает ответ в формате JSON
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,190 @@
+package tblogger
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doLevelRequest выполняет запрос к LevelHandler и разбирает ответ
+func doLevelRequest(t *testing.T, handler http.Handler, method, target, body string) (int, levelResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp levelResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec.Code, resp
+}
+
+// TestLevelHandlerGet тестирует получение текущих уровней
+func TestLevelHandlerGet(t *testing.T) {
+	logger, err := New(&Config{
+		Level:          LevelInfo,
+		Format:         FormatJSON,
+		Output:         io.Discard,
+		LevelOverrides: map[string]LogLevel{"kafka": LevelDebug},
+	})
+	require.NoError(t, err)
+
+	code, resp := doLevelRequest(t, NewLevelHandler(logger), http.MethodGet, "/log/level", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "INFO", resp.Level)
+	assert.Equal(t, map[string]string{"kafka": "DEBUG"}, resp.Components)
+}
+
+// TestLevelHandlerSet тестирует изменение глобального уровня и уровня компонента
+func TestLevelHandlerSet(t *testing.T) {
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: io.Discard,
+	})
+	require.NoError(t, err)
+	handler := NewLevelHandler(logger)
+
+	code, resp := doLevelRequest(t, handler, http.MethodPut, "/log/level", `{"level": "debug"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "DEBUG", resp.Level)
+	assert.True(t, logger.IsDebugEnabled())
+
+	code, resp = doLevelRequest(t, handler, http.MethodPost, "/log/level", `{"level": "error", "component": "db"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"db": "ERROR"}, resp.Components)
+	assert.Equal(t, LevelError, logger.Named("db").LogLevel())
+
+	code, resp = doLevelRequest(t, handler, http.MethodDelete, "/log/level?component=db", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, resp.Components)
+	assert.Equal(t, LevelDebug, logger.Named("db").LogLevel())
+}
+
+// TestLevelHandlerErrors тестирует обработку некорректных запросов
+func TestLevelHandlerErrors(t *testing.T) {
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: io.Discard,
+	})
+	require.NoError(t, err)
+	handler := NewLevelHandler(logger)
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		expected int
+	}{
+		{
+			name:     "invalid json",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"level":`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown level",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"level": "verbose"}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid ttl",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"level": "debug", "ttl": "soon"}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "delete without component",
+			method:   http.MethodDelete,
+			target:   "/",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "unsupported method",
+			method:   http.MethodPatch,
+			target:   "/",
+			expected: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := doLevelRequest(t, handler, tt.method, tt.target, tt.body)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+
+	assert.Equal(t, LevelInfo, logger.LogLevel())
+}
+
+// TestLevelHandlerTTL тестирует автоматический возврат уровня после TTL
+func TestLevelHandlerTTL(t *testing.T) {
+	logger, err := New(&Config{
+		Level:          LevelInfo,
+		Format:         FormatJSON,
+		Output:         io.Discard,
+		LevelOverrides: map[string]LogLevel{"kafka": LevelWarn},
+	})
+	require.NoError(t, err)
+	handler := NewLevelHandler(logger)
+
+	code, resp := doLevelRequest(t, handler, http.MethodPut, "/", `{"level": "debug", "ttl": "50ms"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, resp.Reverts, "*")
+
+	// Повторное изменение продлевает TTL, но возвращает к исходному уровню
+	code, _ = doLevelRequest(t, handler, http.MethodPut, "/", `{"level": "debug", "component": "kafka", "ttl": "50ms"}`)
+	require.Equal(t, http.StatusOK, code)
+	code, resp = doLevelRequest(t, handler, http.MethodPut, "/", `{"level": "error", "component": "kafka", "ttl": "80ms"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, resp.Reverts, "kafka")
+
+	assert.Equal(t, LevelDebug, logger.LogLevel())
+	assert.Equal(t, LevelError, logger.Named("kafka").LogLevel())
+
+	assert.Eventually(t, func() bool {
+		return logger.LogLevel() == LevelInfo && logger.Named("kafka").LogLevel() == LevelWarn
+	}, time.Second, 10*time.Millisecond)
+
+	_, resp = doLevelRequest(t, handler, http.MethodGet, "/", "")
+	assert.Empty(t, resp.Reverts)
+}
+
+// TestLevelHandlerDeleteCancelsRevert тестирует отмену отложенного возврата при удалении переопределения
+func TestLevelHandlerDeleteCancelsRevert(t *testing.T) {
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: io.Discard,
+	})
+	require.NoError(t, err)
+	handler := NewLevelHandler(logger)
+
+	code, resp := doLevelRequest(t, handler, http.MethodPut, "/", `{"level": "debug", "component": "kafka.*", "ttl": "50ms"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, resp.Reverts, "kafka")
+
+	code, resp = doLevelRequest(t, handler, http.MethodDelete, "/?component=kafka.*", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, resp.Reverts)
+	assert.NotContains(t, resp.Components, "kafka")
+
+	// Возврат по истечении TTL не восстанавливает удаленное переопределение
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, logger.levels.snapshot())
+}
@@ -0,0 +1,148 @@
+package tblogger
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultAsyncBufferSize размер очереди асинхронного вывода по умолчанию
+const defaultAsyncBufferSize = 1024
+
+// asyncWriter ставит записи в ограниченную очередь и пишет их в фоновой горутине.
+// Поведение при заполнении очереди определяется OverflowPolicy.
+type asyncWriter struct {
+	out     io.Writer
+	policy  OverflowPolicy
+	queue   chan []byte
+	flushCh chan chan struct{}
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex // защищает closed от гонки с отправкой в queue
+	closed bool
+}
+
+// newAsyncWriter создает асинхронного писателя и запускает фоновую горутину
+func newAsyncWriter(out io.Writer, config *AsyncConfig) *asyncWriter {
+	size := config.BufferSize
+	if size <= 0 {
+		size = defaultAsyncBufferSize
+	}
+
+	w := &asyncWriter{
+		out:     out,
+		policy:  config.Overflow,
+		queue:   make(chan []byte, size),
+		flushCh: make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write ставит копию записи в очередь. После Close пишет синхронно.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return w.out.Write(p)
+	}
+
+	// Обработчик переиспользует буфер, поэтому в очередь ставится копия
+	buf := make([]byte, len(p))
+	copy(buf, p)
+
+	switch w.policy {
+	case OverflowDropNewest:
+		select {
+		case w.queue <- buf:
+		default:
+			w.dropped.Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case w.queue <- buf:
+				return len(p), nil
+			default:
+			}
+			select {
+			case <-w.queue:
+				w.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		w.queue <- buf
+	}
+	return len(p), nil
+}
+
+// Flush дожидается записи всех сообщений, поставленных в очередь до вызова
+func (w *asyncWriter) Flush() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return nil
+	}
+
+	ack := make(chan struct{})
+	w.flushCh <- ack
+	<-ack
+	return nil
+}
+
+// Close записывает оставшиеся сообщения и останавливает фоновую горутину
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+// Dropped возвращает количество отброшенных записей
+func (w *asyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// run пишет записи из очереди до ее закрытия
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for {
+		select {
+		case buf, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			_, _ = w.out.Write(buf)
+		case ack := <-w.flushCh:
+			w.drain()
+			close(ack)
+		}
+	}
+}
+
+// drain пишет все записи, находящиеся в очереди
+func (w *asyncWriter) drain() {
+	for {
+		select {
+		case buf, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			_, _ = w.out.Write(buf)
+		default:
+			return
+		}
+	}
+}
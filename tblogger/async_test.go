@@ -0,0 +1,170 @@
+package tblogger
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingWriter блокирует первую запись до вызова release
+type blockingWriter struct {
+	mu       sync.Mutex
+	lines    []string
+	started  chan struct{}
+	release  chan struct{}
+	blocking sync.Once
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (b *blockingWriter) Write(p []byte) (int, error) {
+	b.blocking.Do(func() {
+		close(b.started)
+		<-b.release
+	})
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lines = append(b.lines, string(p))
+	return len(p), nil
+}
+
+func (b *blockingWriter) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.lines...)
+}
+
+// TestAsyncWriterOverflow тестирует политики переполнения очереди
+func TestAsyncWriterOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OverflowPolicy
+		expected []string
+	}{
+		{
+			name:     "drop newest",
+			policy:   OverflowDropNewest,
+			expected: []string{"first", "second", "third"},
+		},
+		{
+			name:     "drop oldest",
+			policy:   OverflowDropOldest,
+			expected: []string{"first", "fourth", "fifth"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := newBlockingWriter()
+			writer := newAsyncWriter(out, &AsyncConfig{BufferSize: 2, Overflow: tt.policy})
+
+			_, err := writer.Write([]byte("first"))
+			require.NoError(t, err)
+			<-out.started
+
+			for _, msg := range []string{"second", "third", "fourth", "fifth"} {
+				n, err := writer.Write([]byte(msg))
+				require.NoError(t, err)
+				assert.Equal(t, len(msg), n)
+			}
+
+			assert.Equal(t, uint64(2), writer.Dropped())
+
+			close(out.release)
+			require.NoError(t, writer.Close())
+			assert.Equal(t, tt.expected, out.Lines())
+		})
+	}
+}
+
+// TestAsyncWriterBlock тестирует политику ожидания и Flush
+func TestAsyncWriterBlock(t *testing.T) {
+	out := newBlockingWriter()
+	writer := newAsyncWriter(out, &AsyncConfig{BufferSize: 1, Overflow: OverflowBlock})
+
+	_, err := writer.Write([]byte("first"))
+	require.NoError(t, err)
+	<-out.started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, msg := range []string{"second", "third", "fourth"} {
+			_, _ = writer.Write([]byte(msg))
+		}
+	}()
+
+	close(out.release)
+	<-done
+	require.NoError(t, writer.Flush())
+
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, out.Lines())
+	assert.Equal(t, uint64(0), writer.Dropped())
+	require.NoError(t, writer.Close())
+
+	// После Close запись выполняется синхронно
+	_, err = writer.Write([]byte("after close"))
+	require.NoError(t, err)
+	assert.Contains(t, out.Lines(), "after close")
+}
+
+// TestLoggerAsync тестирует асинхронный логгер с Flush и Close
+func TestLoggerAsync(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		Async:  &AsyncConfig{BufferSize: 16},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		logger.With("i", i).Info("async message")
+	}
+	require.NoError(t, logger.Flush())
+	assert.Equal(t, 10, strings.Count(mockWriter.String(), "async message"))
+
+	logger.Info("last message")
+	require.NoError(t, logger.Close())
+	assert.Contains(t, mockWriter.String(), "last message")
+	assert.Equal(t, uint64(0), logger.DroppedRecords())
+
+	// Повторное закрытие безопасно
+	require.NoError(t, logger.Close())
+}
+
+// TestLoggerAsyncFatalFlushes тестирует сброс очереди перед завершением программы
+func TestLoggerAsyncFatalFlushes(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		Async:  &AsyncConfig{},
+	})
+	require.NoError(t, err)
+	defer logger.Close()
+
+	originalOsExit := osExit
+	defer func() {
+		osExit = originalOsExit
+	}()
+
+	var output string
+	osExit = func(code int) {
+		output = mockWriter.String()
+	}
+
+	logger.Fatal("fatal message")
+	assert.Contains(t, output, "fatal message")
+}
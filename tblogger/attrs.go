@@ -0,0 +1,103 @@
+package tblogger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Attr поле записи. Типизированные конструкторы ниже не упаковывают значения
+// в interface{} и вместе с методами *Attrs не выделяют память на каждое поле.
+type Attr = slog.Attr
+
+// String возвращает строковое поле
+func String(key, value string) Attr {
+	return slog.String(key, value)
+}
+
+// Int возвращает целочисленное поле
+func Int(key string, value int) Attr {
+	return slog.Int(key, value)
+}
+
+// Int64 возвращает целочисленное поле
+func Int64(key string, value int64) Attr {
+	return slog.Int64(key, value)
+}
+
+// Uint64 возвращает беззнаковое целочисленное поле
+func Uint64(key string, value uint64) Attr {
+	return slog.Uint64(key, value)
+}
+
+// Float64 возвращает поле с плавающей точкой
+func Float64(key string, value float64) Attr {
+	return slog.Float64(key, value)
+}
+
+// Bool возвращает логическое поле
+func Bool(key string, value bool) Attr {
+	return slog.Bool(key, value)
+}
+
+// Duration возвращает поле длительности
+func Duration(key string, value time.Duration) Attr {
+	return slog.Duration(key, value)
+}
+
+// Time возвращает поле времени
+func Time(key string, value time.Time) Attr {
+	return slog.Time(key, value)
+}
+
+// Any возвращает поле произвольного типа
+func Any(key string, value interface{}) Attr {
+	return slog.Any(key, value)
+}
+
+// Group возвращает группу полей
+func Group(key string, attrs ...Attr) Attr {
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
+
+// Err возвращает поле error в том же виде, что и WithError (nil — пустое поле, которое не выводится)
+func Err(err error) Attr {
+	if err == nil {
+		return Attr{}
+	}
+	return errorAttr(errorKey, err)
+}
+
+// LogAttrs логирует сообщение с типизированными полями на указанном уровне.
+// Если уровень отключен, поля не обрабатываются.
+func (l *Logger) LogAttrs(ctx context.Context, level LogLevel, msg string, attrs ...Attr) {
+	l.logAttrs(ctx, 0, slog.Level(level), msg, attrs...)
+}
+
+// DebugAttrs логирует сообщение с типизированными полями на уровне DEBUG
+func (l *Logger) DebugAttrs(ctx context.Context, msg string, attrs ...Attr) {
+	l.logAttrs(ctx, 0, slog.LevelDebug, msg, attrs...)
+}
+
+// InfoAttrs логирует сообщение с типизированными полями на уровне INFO
+func (l *Logger) InfoAttrs(ctx context.Context, msg string, attrs ...Attr) {
+	l.logAttrs(ctx, 0, slog.LevelInfo, msg, attrs...)
+}
+
+// WarnAttrs логирует сообщение с типизированными полями на уровне WARN
+func (l *Logger) WarnAttrs(ctx context.Context, msg string, attrs ...Attr) {
+	l.logAttrs(ctx, 0, slog.LevelWarn, msg, attrs...)
+}
+
+// ErrorAttrs логирует сообщение с типизированными полями на уровне ERROR
+func (l *Logger) ErrorAttrs(ctx context.Context, msg string, attrs ...Attr) {
+	l.logAttrs(ctx, 0, slog.LevelError, msg, attrs...)
+}
+
+// WithAttrs возвращает новый логгер с дополнительными типизированными полями
+func (l *Logger) WithAttrs(attrs ...Attr) *Logger {
+	if len(attrs) == 0 {
+		return l
+	}
+	return l.derive(slog.New(l.slogger.Handler().WithAttrs(attrs)))
+}
@@ -0,0 +1,151 @@
+package tblogger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogAttrs тестирует вывод типизированных полей
+func TestLogAttrs(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	logger.WithAttrs(String("component_id", "c-1")).InfoAttrs(ctx, "typed",
+		String("s", "v"),
+		Int("i", 1),
+		Int64("i64", -2),
+		Uint64("u64", 3),
+		Float64("f", 1.5),
+		Bool("b", true),
+		Duration("d", time.Second),
+		Time("t", time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)),
+		Any("any", []int{1, 2}),
+		Group("g", Int("n", 7)),
+		Err(errors.New("failed")),
+		Err(nil),
+	)
+	logger.DebugAttrs(ctx, "disabled", String("s", "v"))
+	logger.WarnAttrs(ctx, "warn")
+	logger.ErrorAttrs(ctx, "error")
+	logger.LogAttrs(ctx, LevelWarn, "custom level")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 4)
+
+	r := records[0]
+	assert.Equal(t, "INFO", r["level"])
+	assert.Equal(t, "c-1", r["component_id"])
+	assert.Equal(t, "v", r["s"])
+	assert.Equal(t, float64(1), r["i"])
+	assert.Equal(t, float64(-2), r["i64"])
+	assert.Equal(t, float64(3), r["u64"])
+	assert.Equal(t, 1.5, r["f"])
+	assert.Equal(t, true, r["b"])
+	assert.Equal(t, float64(time.Second), r["d"])
+	assert.Equal(t, "2025-03-10T14:00:00Z", r["t"])
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, r["any"])
+	assert.Equal(t, map[string]interface{}{"n": float64(7)}, r["g"])
+	assert.Equal(t, "failed", r["error"].(map[string]interface{})["message"])
+
+	assert.Equal(t, "WARN", records[1]["level"])
+	assert.Equal(t, "ERROR", records[2]["level"])
+	assert.Equal(t, "custom level", records[3]["msg"])
+}
+
+// Значения полей для бенчмарков (переменные, чтобы компилятор не заменил их константами)
+var (
+	benchMethod   = "GET"
+	benchStatus   = 200
+	benchSize     = int64(1024)
+	benchCached   = true
+	benchDuration = 150 * time.Millisecond
+)
+
+// newBenchmarkLogger создает логгер, пишущий JSON в io.Discard
+func newBenchmarkLogger(b *testing.B, level LogLevel) *Logger {
+	b.Helper()
+
+	logger, err := New(&Config{
+		Level:  level,
+		Format: FormatJSON,
+		Output: io.Discard,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return logger
+}
+
+// BenchmarkInfoArgs измеряет Info с пятью полями в формате ключ-значение
+func BenchmarkInfoArgs(b *testing.B) {
+	logger := newBenchmarkLogger(b, LevelInfo)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled",
+			"method", benchMethod,
+			"status", benchStatus,
+			"size", benchSize,
+			"cached", benchCached,
+			"duration", benchDuration,
+		)
+	}
+}
+
+// BenchmarkInfoAttrs измеряет InfoAttrs с пятью типизированными полями
+func BenchmarkInfoAttrs(b *testing.B) {
+	logger := newBenchmarkLogger(b, LevelInfo)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.InfoAttrs(ctx, "request handled",
+			String("method", benchMethod),
+			Int("status", benchStatus),
+			Int64("size", benchSize),
+			Bool("cached", benchCached),
+			Duration("duration", benchDuration),
+		)
+	}
+}
+
+// BenchmarkInfoArgsDisabled измеряет Info с пятью полями при отключенном уровне
+func BenchmarkInfoArgsDisabled(b *testing.B) {
+	logger := newBenchmarkLogger(b, LevelError)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled",
+			"method", benchMethod,
+			"status", benchStatus,
+			"size", benchSize,
+			"cached", benchCached,
+			"duration", benchDuration,
+		)
+	}
+}
+
+// BenchmarkInfoAttrsDisabled измеряет InfoAttrs с пятью полями при отключенном уровне
+func BenchmarkInfoAttrsDisabled(b *testing.B) {
+	logger := newBenchmarkLogger(b, LevelError)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.InfoAttrs(ctx, "request handled",
+			String("method", benchMethod),
+			Int("status", benchStatus),
+			Int64("size", benchSize),
+			Bool("cached", benchCached),
+			Duration("duration", benchDuration),
+		)
+	}
+}
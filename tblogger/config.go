@@ -1,8 +1,11 @@
 package tblogger
 
 import (
+	"fmt"
 	"io"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,34 +14,132 @@ type LogLevel slog.Level
 
 // Предопределенные уровни логирования
 const (
-	LevelDebug LogLevel = LogLevel(slog.LevelDebug)
-	LevelInfo  LogLevel = LogLevel(slog.LevelInfo)
-	LevelWarn  LogLevel = LogLevel(slog.LevelWarn)
-	LevelError LogLevel = LogLevel(slog.LevelError)
+	LevelTrace  LogLevel = LogLevel(slog.LevelDebug - 4)
+	LevelDebug  LogLevel = LogLevel(slog.LevelDebug)
+	LevelInfo   LogLevel = LogLevel(slog.LevelInfo)
+	LevelNotice LogLevel = LogLevel(slog.LevelInfo + 2)
+	LevelWarn   LogLevel = LogLevel(slog.LevelWarn)
+	LevelError  LogLevel = LogLevel(slog.LevelError)
+	LevelPanic  LogLevel = LogLevel(slog.LevelError + 4)
+	LevelFatal  LogLevel = LogLevel(slog.LevelError + 8)
 )
 
-// String возвращает строковое представление уровня логирования
+// levelNames имена предопределенных уровней в порядке возрастания
+var levelNames = []struct {
+	level LogLevel
+	name  string
+}{
+	{LevelTrace, "TRACE"},
+	{LevelDebug, "DEBUG"},
+	{LevelInfo, "INFO"},
+	{LevelNotice, "NOTICE"},
+	{LevelWarn, "WARN"},
+	{LevelError, "ERROR"},
+	{LevelPanic, "PANIC"},
+	{LevelFatal, "FATAL"},
+}
+
+// levelAliases дополнительные имена уровней, принимаемые ParseLevel
+var levelAliases = map[string]LogLevel{
+	"WARNING":  LevelWarn,
+	"CRITICAL": LevelFatal,
+}
+
+// String возвращает строковое представление уровня логирования. Уровни между
+// предопределенными записываются смещением от ближайшего меньшего: "INFO+1".
 func (l LogLevel) String() string {
-	switch l {
-	case LevelDebug:
-		return "DEBUG"
-	case LevelInfo:
-		return "INFO"
-	case LevelWarn:
-		return "WARN"
-	case LevelError:
-		return "ERROR"
+	base := levelNames[0]
+	for _, ln := range levelNames {
+		if ln.level > l {
+			break
+		}
+		base = ln
+	}
+
+	switch {
+	case l == base.level:
+		return base.name
+	case l > base.level:
+		return fmt.Sprintf("%s+%d", base.name, l-base.level)
 	default:
-		return "UNKNOWN"
+		return fmt.Sprintf("%s%d", base.name, l-base.level)
 	}
 }
 
+// ParseLevel разбирает строковое представление уровня логирования без учета
+// регистра: имя уровня (TRACE, DEBUG, INFO, NOTICE, WARN, ERROR, PANIC, FATAL,
+// а также WARNING и CRITICAL) со смещением или без него, например "INFO+1"
+func ParseLevel(s string) (LogLevel, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+
+	offset := 0
+	if i := strings.IndexAny(name, "+-"); i > 0 {
+		n, err := strconv.Atoi(name[i:])
+		if err != nil {
+			return 0, fmt.Errorf("unknown log level: %q", s)
+		}
+		name, offset = name[:i], n
+	}
+
+	if level, ok := levelAliases[name]; ok {
+		return level + LogLevel(offset), nil
+	}
+	for _, ln := range levelNames {
+		if ln.name == name {
+			return ln.level + LogLevel(offset), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level: %q", s)
+}
+
+// MarshalText реализует encoding.TextMarshaler
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText реализует encoding.TextUnmarshaler
+func (l *LogLevel) UnmarshalText(data []byte) error {
+	level, err := ParseLevel(string(data))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
 // OutputFormat определяет формат вывода логов
 type OutputFormat string
 
 const (
-	FormatJSON OutputFormat = "json"
-	FormatText OutputFormat = "text"
+	FormatJSON    OutputFormat = "json"
+	FormatText    OutputFormat = "text"
+	FormatConsole OutputFormat = "console" // цветной формат для локальной разработки
+	FormatLogfmt  OutputFormat = "logfmt"  // строгий logfmt, группы раскрываются через точку
+)
+
+// Sink описывает одно направление вывода логов. Все выводы логгера разделяют
+// DefaultFields, сведения о сервисе и параметры ротации файлов из Config.
+type Sink struct {
+	// Минимальный уровень записей для этого вывода
+	Level LogLevel
+
+	// Формат вывода (json/text/console/logfmt, пусто — json)
+	Format OutputFormat
+
+	// Вывод логов (файл или stdout/stderr)
+	Output io.Writer
+
+	// Путь к файлу логов (если нужно писать в файл)
+	FilePath string
+
+	// Включать ли информацию о коде (файл, строка)
+	AddSource bool
+}
+
+// Периоды ротации файла логов по времени
+const (
+	RotateHourly = time.Hour
+	RotateDaily  = 24 * time.Hour
 )
 
 // Config содержит конфигурацию логгера
@@ -46,7 +147,11 @@ type Config struct {
 	// Уровень логирования
 	Level LogLevel
 
-	// Формат вывода (json/text)
+	// Переопределения уровня для именованных логгеров (см. Logger.Named).
+	// Ключ — имя компонента или префикс имени: "kafka" действует и на "kafka.consumer".
+	LevelOverrides map[string]LogLevel
+
+	// Формат вывода (json/text/console/logfmt, пусто — json)
 	Format OutputFormat
 
 	// Вывод логов (файл или stdout/stderr)
@@ -55,12 +160,26 @@ type Config struct {
 	// Путь к файлу логов (если нужно писать в файл)
 	FilePath string
 
-	// Максимальный размер файла в MB (для ротации)
+	// Несколько выводов с независимыми уровнем и форматом. Если заданы,
+	// Format, Output, FilePath и AddSource игнорируются, а Level остается
+	// общим уровнем логгера, после которого применяется уровень вывода.
+	Sinks []Sink
+
+	// Максимальный размер файла в MB, при превышении файл ротируется (0 — без ротации)
 	MaxFileSize int64
 
-	// Количество файлов для ротации
+	// Количество хранимых архивных файлов после ротации (0 — хранить все)
 	MaxFiles int
 
+	// Период ротации по времени, например RotateDaily или RotateHourly (0 — без ротации по времени)
+	RotateEvery time.Duration
+
+	// Срок хранения архивных файлов (0 — без ограничения)
+	MaxAge time.Duration
+
+	// Сжимать ли архивные файлы gzip
+	Compress bool
+
 	// Включать ли информацию о коде (файл, строка)
 	AddSource bool
 
@@ -78,4 +197,116 @@ type Config struct {
 
 	// Временная зона
 	TimeZone *time.Location
+
+	// Имена служебных полей записи (время, уровень, сообщение, источник, сведения
+	// о сервисе, трассировка) для системы сбора логов (по умолчанию имена slog).
+	// Поля верхнего уровня с именами, занятыми схемой, выводятся с префиксом "fields.".
+	Schema Schema
+
+	// Проект Google Cloud для поля logging.googleapis.com/trace схемы SchemaGCP:
+	// идентификатор трассировки выводится как projects/<проект>/traces/<trace_id>
+	GCPProjectID string
+
+	// Асинхронная запись через ограниченную очередь (nil — синхронная запись)
+	Async *AsyncConfig
+
+	// Сэмплирование повторяющихся сообщений (nil — без сэмплирования)
+	Sampling *SamplingConfig
+
+	// Схлопывание одинаковых записей (nil — без дедупликации)
+	Dedup *DedupConfig
+
+	// Маскирование чувствительных данных (nil — без маскирования), см. DefaultRedactConfig
+	Redact *RedactConfig
+
+	// Псевдонимизация идентификаторов ключевым хешем (nil — без псевдонимизации)
+	Pseudonymize *PseudonymizeConfig
+
+	// Источник контекста трассировки для полей trace_id и span_id
+	// (nil — контекст, сохраненный через ContextWithSpan)
+	SpanSource SpanSource
+
+	// Дополнительное число кадров стека, пропускаемых при определении источника
+	// записи (AddSource), для оберток над логгером
+	CallerSkip int
+}
+
+// PseudonymizeConfig содержит настройки псевдонимизации. Значения полей из Keys
+// заменяются группой {"key_id": KeyID, "hash": HMAC-SHA256 значения (32 hex-символа)}.
+// Одинаковые значения при одном ключе дают одинаковый хеш, поэтому записи разных
+// сервисов с общим ключом можно сопоставлять без хранения исходного значения.
+type PseudonymizeConfig struct {
+	// Имена полей, значения которых псевдонимизируются (без учета регистра,
+	// в том числе внутри групп)
+	Keys []string
+
+	// Идентификатор ключа, выводимый вместе с хешем (обязателен)
+	KeyID string
+
+	// Секретный ключ HMAC (обязателен). Ключ можно заменить без перезапуска через Logger.SetPseudonymKey.
+	Key []byte
+}
+
+// RedactConfig содержит настройки маскирования чувствительных данных
+type RedactConfig struct {
+	// Имена полей, значения которых маскируются целиком (без учета регистра,
+	// в том числе внутри групп и для всех полей группы с таким именем)
+	Keys []string
+
+	// Шаблоны поиска чувствительных данных в строковых значениях и сообщении
+	Patterns []RedactPattern
+
+	// Способ маскирования (по умолчанию MaskFull)
+	Mask MaskStyle
+
+	// Секретный ключ HMAC для MaskHash (обязателен при MaskHash). Для сопоставления
+	// идентификаторов между сервисами используйте Config.Pseudonymize.
+	HashKey []byte
+}
+
+// DedupConfig содержит настройки дедупликации. Одинаковые записи (уровень,
+// сообщение и поля), пришедшие в течение Window после первой, не выводятся;
+// по окончании окна выводится одна запись с полями repeat_count (количество
+// подавленных повторов), first_seen и last_seen.
+type DedupConfig struct {
+	// Окно дедупликации (по умолчанию 1 секунда)
+	Window time.Duration
+}
+
+// SamplingConfig содержит настройки сэмплирования. Записи группируются по
+// сообщению и уровню: за каждый Interval логируются первые Initial записей,
+// затем каждая Thereafter-я.
+type SamplingConfig struct {
+	// Количество первых записей, логируемых за интервал
+	Initial int
+
+	// После Initial логируется каждая Thereafter-я запись (0 — остальные подавляются)
+	Thereafter int
+
+	// Интервал сэмплирования (по умолчанию 1 секунда)
+	Interval time.Duration
+
+	// Не сэмплировать записи уровня ERROR и выше
+	ExemptErrors bool
+
+	// Период отчета о количестве подавленных записей (по умолчанию 1 минута, отрицательное значение отключает отчеты)
+	ReportInterval time.Duration
+}
+
+// OverflowPolicy определяет поведение асинхронной записи при заполненной очереди
+type OverflowPolicy string
+
+const (
+	OverflowBlock      OverflowPolicy = "block"       // ждать освобождения места в очереди
+	OverflowDropNewest OverflowPolicy = "drop_newest" // отбросить новую запись
+	OverflowDropOldest OverflowPolicy = "drop_oldest" // отбросить самую старую запись из очереди
+)
+
+// AsyncConfig содержит настройки асинхронной записи
+type AsyncConfig struct {
+	// Размер очереди в записях (по умолчанию 1024)
+	BufferSize int
+
+	// Поведение при заполненной очереди (по умолчанию OverflowBlock)
+	Overflow OverflowPolicy
 }
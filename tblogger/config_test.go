@@ -1,12 +1,14 @@
 package tblogger
 
 import (
+	"encoding/json"
 	"io"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestLogLevel тестирует уровни логирования
@@ -36,6 +38,36 @@ func TestLogLevel(t *testing.T) {
 			level:    LevelError,
 			expected: "ERROR",
 		},
+		{
+			name:     "Trace level",
+			level:    LevelTrace,
+			expected: "TRACE",
+		},
+		{
+			name:     "Notice level",
+			level:    LevelNotice,
+			expected: "NOTICE",
+		},
+		{
+			name:     "Panic level",
+			level:    LevelPanic,
+			expected: "PANIC",
+		},
+		{
+			name:     "Fatal level",
+			level:    LevelFatal,
+			expected: "FATAL",
+		},
+		{
+			name:     "Level between predefined",
+			level:    LevelInfo + 1,
+			expected: "INFO+1",
+		},
+		{
+			name:     "Level below trace",
+			level:    LevelTrace - 2,
+			expected: "TRACE-2",
+		},
 	}
 
 	for _, tt := range tests {
@@ -45,6 +77,99 @@ func TestLogLevel(t *testing.T) {
 	}
 }
 
+// TestParseLevel тестирует разбор строкового представления уровня
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected LogLevel
+		wantErr  bool
+	}{
+		{input: "trace", expected: LevelTrace},
+		{input: "DEBUG", expected: LevelDebug},
+		{input: " Info ", expected: LevelInfo},
+		{input: "notice", expected: LevelNotice},
+		{input: "warning", expected: LevelWarn},
+		{input: "error", expected: LevelError},
+		{input: "panic", expected: LevelPanic},
+		{input: "fatal", expected: LevelFatal},
+		{input: "critical", expected: LevelFatal},
+		{input: "INFO+1", expected: LevelInfo + 1},
+		{input: "debug-2", expected: LevelDebug - 2},
+		{input: "verbose", wantErr: true},
+		{input: "INFO+x", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := ParseLevel(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+// TestLogLevelText тестирует текстовую и JSON сериализацию уровня
+func TestLogLevelText(t *testing.T) {
+	data, err := json.Marshal(map[string]LogLevel{"level": LevelNotice})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"level":"NOTICE"}`, string(data))
+
+	var decoded struct {
+		Level LogLevel `json:"level"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"level":"trace"}`), &decoded))
+	assert.Equal(t, LevelTrace, decoded.Level)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"level":"verbose"}`), &decoded))
+
+	// Значение String разбирается обратно в тот же уровень
+	for level := LevelTrace - 2; level <= LevelFatal+2; level++ {
+		parsed, err := ParseLevel(level.String())
+		require.NoError(t, err)
+		assert.Equal(t, level, parsed)
+	}
+}
+
+// TestLevelNamesInOutput тестирует имена дополнительных уровней в выводе
+func TestLevelNamesInOutput(t *testing.T) {
+	originalOsExit := osExit
+	defer func() {
+		osExit = originalOsExit
+	}()
+	osExit = func(code int) {}
+
+	for _, format := range []OutputFormat{FormatJSON, FormatText} {
+		t.Run(string(format), func(t *testing.T) {
+			mockWriter := NewMockWriter()
+			logger, err := New(&Config{
+				Level:  LevelTrace,
+				Format: format,
+				Output: mockWriter,
+			})
+			require.NoError(t, err)
+
+			logger.Trace("trace message")
+			logger.Notice("notice message")
+			logger.Fatal("fatal message")
+			assert.Panics(t, func() {
+				logger.Panic("panic message")
+			})
+
+			output := mockWriter.String()
+			for _, name := range []string{"TRACE", "NOTICE", "FATAL", "PANIC"} {
+				assert.Contains(t, output, name)
+			}
+			assert.NotContains(t, output, "DEBUG-4")
+			assert.NotContains(t, output, "ERROR+")
+		})
+	}
+}
+
 // TestOutputFormat тестирует форматы вывода
 func TestOutputFormat(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,354 @@
+package tblogger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Параметры разметки консольного формата
+const (
+	consoleTimeFormat   = "15:04:05.000"
+	consoleLevelWidth   = 6
+	consoleMessageWidth = 40
+	consoleIndent       = "    "
+)
+
+// ANSI-последовательности цветов консольного формата
+const (
+	colorReset   = "\x1b[0m"
+	colorBold    = "\x1b[1m"
+	colorDim     = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+	colorGray    = "\x1b[90m"
+)
+
+// levelColor возвращает цвет уровня
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.Level(LevelPanic):
+		return colorBold + colorMagenta
+	case level >= slog.Level(LevelError):
+		return colorRed
+	case level >= slog.Level(LevelWarn):
+		return colorYellow
+	case level >= slog.Level(LevelNotice):
+		return colorCyan
+	case level >= slog.Level(LevelInfo):
+		return colorGreen
+	case level >= slog.Level(LevelDebug):
+		return colorBlue
+	default:
+		return colorGray
+	}
+}
+
+// colorEnabled сообщает, нужно ли раскрашивать вывод: вывод — терминал и не задан NO_COLOR
+func colorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok || f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// flatField поле записи с ключом, составленным из имен групп через точку
+type flatField struct {
+	key   string
+	value slog.Value
+}
+
+// consoleHandler выводит записи в удобном для чтения виде: короткое время,
+// выровненные уровень и сообщение, затем поля. Многострочные значения
+// (например, стек вызовов) выводятся отдельным блоком под записью.
+type consoleHandler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	opts   slog.HandlerOptions
+	color  bool
+	fields []flatField // поля, добавленные через WithAttrs
+	groups []string    // группы, открытые через WithGroup
+}
+
+// newConsoleHandler создает обработчик консольного формата
+func newConsoleHandler(w io.Writer, opts *slog.HandlerOptions, color bool) *consoleHandler {
+	h := &consoleHandler{w: w, mu: &sync.Mutex{}, color: color}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Enabled реализует slog.Handler
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// WithAttrs реализует slog.Handler
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.fields = append([]flatField(nil), h.fields...)
+	for _, a := range attrs {
+		h2.fields = appendFlatField(h.opts.ReplaceAttr, h2.fields, h.groups, a)
+	}
+	return &h2
+}
+
+// WithGroup реализует slog.Handler
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &h2
+}
+
+// Handle реализует slog.Handler
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+
+	// Время
+	if !r.Time.IsZero() {
+		if a := replaceBuiltin(h.opts.ReplaceAttr, slog.Time(slog.TimeKey, r.Time)); a.Key != "" {
+			h.colorize(&buf, colorDim, consoleValueString(a.Value, consoleTimeFormat))
+			buf.WriteByte(' ')
+		}
+	}
+
+	// Уровень
+	if a := replaceBuiltin(h.opts.ReplaceAttr, slog.Any(slog.LevelKey, r.Level)); a.Key != "" {
+		level := a.Value.String()
+		h.colorize(&buf, levelColor(r.Level), level)
+		writePadding(&buf, level, consoleLevelWidth)
+	}
+
+	// Сообщение: первая строка в колонке, остальные строки блоком под записью
+	msg, msgKey := r.Message, slog.MessageKey
+	if a := replaceBuiltin(h.opts.ReplaceAttr, slog.String(slog.MessageKey, r.Message)); a.Key != "" {
+		msg, msgKey = a.Value.String(), a.Key
+	}
+	msgLines := strings.Split(strings.TrimRight(msg, "\n"), "\n")
+	for i, line := range msgLines {
+		msgLines[i] = escapeConsole(line)
+	}
+	msg = msgLines[0]
+	h.colorize(&buf, colorBold, msg)
+
+	// Поля
+	fields := h.fields
+	if h.opts.AddSource && r.PC != 0 {
+		fields = appendFlatField(h.opts.ReplaceAttr, fields[:len(fields):len(fields)], nil, slog.String(slog.SourceKey, sourceString(r.PC)))
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendFlatField(h.opts.ReplaceAttr, fields[:len(fields):len(fields)], h.groups, a)
+		return true
+	})
+
+	var blocks []flatField
+	if len(msgLines) > 1 {
+		blocks = append(blocks, flatField{key: msgKey, value: slog.AnyValue(msgLines[1:])})
+	}
+	padded := false
+	for _, f := range fields {
+		lines, multiline := consoleLines(f)
+		if multiline {
+			f.value = slog.AnyValue(lines)
+			blocks = append(blocks, f)
+			continue
+		}
+
+		if !padded {
+			writePadding(&buf, msg, consoleMessageWidth)
+			padded = true
+		} else {
+			buf.WriteByte(' ')
+		}
+		h.colorize(&buf, colorDim, f.key+"=")
+		buf.WriteString(quoteConsoleValue(consoleValueString(f.value, time.RFC3339Nano)))
+	}
+	buf.WriteByte('\n')
+
+	// Многострочные значения
+	for _, f := range blocks {
+		buf.WriteString(consoleIndent)
+		h.colorize(&buf, colorDim, f.key+":")
+		buf.WriteByte('\n')
+		for _, line := range f.value.Any().([]string) {
+			buf.WriteString(consoleIndent + consoleIndent)
+			buf.WriteString(escapeConsole(line))
+			buf.WriteByte('\n')
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+// replaceBuiltin применяет replaceAttr к встроенному полю записи
+func replaceBuiltin(replaceAttr func([]string, slog.Attr) slog.Attr, a slog.Attr) slog.Attr {
+	if replaceAttr == nil {
+		return a
+	}
+	a = replaceAttr(nil, a)
+	a.Value = a.Value.Resolve()
+	return a
+}
+
+// appendFlatField добавляет поле, применяя replaceAttr так же, как обработчики
+// slog, и раскрывая группы в ключи через точку
+func appendFlatField(replaceAttr func([]string, slog.Attr) slog.Attr, fields []flatField, groups []string, a slog.Attr) []flatField {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup && replaceAttr != nil {
+		a = replaceAttr(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+	if a.Key == "" && a.Value.Kind() == slog.KindAny && a.Value.Any() == nil {
+		return fields
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		children := groups
+		if a.Key != "" {
+			children = append(groups[:len(groups):len(groups)], a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendFlatField(replaceAttr, fields, children, ga)
+		}
+		return fields
+	}
+
+	if a.Key == "" {
+		return fields
+	}
+
+	key := a.Key
+	if len(groups) > 0 {
+		key = strings.Join(groups, ".") + "." + key
+	}
+	return append(fields, flatField{key: key, value: a.Value})
+}
+
+// colorize выводит строку указанным цветом, если цвета включены
+func (h *consoleHandler) colorize(buf *bytes.Buffer, color, s string) {
+	if !h.color {
+		buf.WriteString(s)
+		return
+	}
+	buf.WriteString(color)
+	buf.WriteString(s)
+	buf.WriteString(colorReset)
+}
+
+// writePadding дополняет колонку пробелами до ширины width (минимум один пробел)
+func writePadding(buf *bytes.Buffer, s string, width int) {
+	n := width - utf8.RuneCountInString(s)
+	if n < 1 {
+		n = 1
+	}
+	buf.WriteString(strings.Repeat(" ", n))
+}
+
+// consoleLines возвращает строки многострочного значения: текста с переводами
+// строк или списка строк (например, стека вызовов из WithError)
+func consoleLines(f flatField) ([]string, bool) {
+	switch f.value.Kind() {
+	case slog.KindString:
+		s := f.value.String()
+		if strings.Contains(s, "\n") {
+			return strings.Split(strings.TrimRight(s, "\n"), "\n"), true
+		}
+	case slog.KindAny:
+		if lines, ok := f.value.Any().([]string); ok && len(lines) > 0 {
+			return lines, true
+		}
+	}
+	return nil, false
+}
+
+// consoleValueString возвращает строковое представление значения
+func consoleValueString(v slog.Value, timeFormat string) string {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(timeFormat)
+	case slog.KindDuration:
+		return v.Duration().String()
+	default:
+		return v.String()
+	}
+}
+
+// escapeConsole экранирует управляющие и непечатаемые символы строки (например,
+// \r или ANSI-последовательности), чтобы сообщение не подделывало разметку вывода
+func escapeConsole(s string) string {
+	clean := true
+	for _, r := range s {
+		if r < ' ' || r == 0x7f || !strconv.IsPrint(r) && r != ' ' {
+			clean = false
+			break
+		}
+	}
+	if clean {
+		return s
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		if r < ' ' || r == 0x7f || !strconv.IsPrint(r) && r != ' ' {
+			q := strconv.QuoteRune(r)
+			b.WriteString(q[1 : len(q)-1])
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// quoteConsoleValue заключает значение в кавычки, если оно пустое или содержит
+// пробелы, кавычки, знак равенства или управляющие символы
+func quoteConsoleValue(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' || r == 0x7f || !strconv.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
+
+// sourceString возвращает место вызова в виде dir/file.go:line
+func sourceString(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	dir, file := filepath.Split(frame.File)
+	return fmt.Sprintf("%s:%d", filepath.Join(filepath.Base(dir), file), frame.Line)
+}
@@ -0,0 +1,127 @@
+package tblogger
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tvoybuket/tblib/tberrors"
+)
+
+// newConsoleLogger создает логгер консольного формата
+func newConsoleLogger(t *testing.T, level LogLevel) (*Logger, *MockWriter) {
+	t.Helper()
+
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:          level,
+		Format:         FormatConsole,
+		Output:         mockWriter,
+		ServiceName:    "billing",
+		ServiceVersion: "1.0.0",
+		Environment:    "local",
+		TimeZone:       time.UTC,
+	})
+	require.NoError(t, err)
+	return logger, mockWriter
+}
+
+// TestConsoleFormat тестирует разметку записи консольного формата
+func TestConsoleFormat(t *testing.T) {
+	logger, mockWriter := newConsoleLogger(t, LevelTrace)
+
+	logger.WithGroup("http").Info("request handled", "status", 200, "path", "/api users")
+	logger.Notice("short")
+
+	lines := strings.Split(strings.TrimRight(mockWriter.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	// Время, уровень и сообщение в выровненных колонках
+	line := lines[0]
+	_, err := time.Parse(consoleTimeFormat, line[:len(consoleTimeFormat)])
+	require.NoError(t, err)
+	rest := line[len(consoleTimeFormat)+1:]
+	assert.True(t, strings.HasPrefix(rest, "INFO  request handled"), rest)
+	assert.Equal(t, consoleLevelWidth+consoleMessageWidth, strings.Index(rest, "service="))
+	assert.Contains(t, rest, "service=billing version=1.0.0 environment=local")
+	assert.Contains(t, rest, `http.status=200 http.path="/api users"`)
+	assert.NotContains(t, rest, "\x1b[")
+
+	assert.Equal(t, "NOTICE short", lines[1][len(consoleTimeFormat)+1:len(consoleTimeFormat)+1+len("NOTICE short")])
+}
+
+// TestConsoleMultiline тестирует вывод многострочных значений блоком под записью
+func TestConsoleMultiline(t *testing.T) {
+	logger, mockWriter := newConsoleLogger(t, LevelInfo)
+
+	logger.WithError(tberrors.New("db unavailable")).Error("query failed", "note", "line one\nline two")
+
+	lines := strings.Split(strings.TrimRight(mockWriter.String(), "\n"), "\n")
+	require.Greater(t, len(lines), 4)
+	assert.Contains(t, lines[0], `error.message="db unavailable"`)
+	assert.NotContains(t, lines[0], "error.stack")
+
+	output := mockWriter.String()
+	assert.Contains(t, output, "\n"+consoleIndent+"error.stack:\n"+consoleIndent+consoleIndent)
+	assert.Contains(t, output, "TestConsoleMultiline")
+	assert.Contains(t, output, consoleIndent+"note:\n"+consoleIndent+consoleIndent+"line one\n"+consoleIndent+consoleIndent+"line two\n")
+}
+
+// TestConsoleColor тестирует раскрашивание уровней
+func TestConsoleColor(t *testing.T) {
+	mockWriter := NewMockWriter()
+	h := newConsoleHandler(mockWriter, nil, true)
+	logger := &Logger{slogger: slog.New(h), config: DefaultConfig()}
+
+	logger.Warn("careful", "key", "value")
+
+	output := mockWriter.String()
+	assert.Contains(t, output, colorYellow+"WARN"+colorReset)
+	assert.Contains(t, output, colorDim+"key="+colorReset+"value")
+}
+
+// TestColorEnabled тестирует определение поддержки цветов
+func TestColorEnabled(t *testing.T) {
+	assert.False(t, colorEnabled(NewMockWriter()))
+	assert.False(t, colorEnabled(nil))
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	require.NoError(t, err)
+	defer f.Close()
+	assert.False(t, colorEnabled(f))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, colorEnabled(os.Stdout))
+}
+
+// TestConsoleMultilineMessage тестирует вывод многострочного сообщения и экранирование управляющих символов
+func TestConsoleMultilineMessage(t *testing.T) {
+	logger, mockWriter := newConsoleLogger(t, LevelInfo)
+
+	logger.Info("query failed\nSELECT *\n  FROM users", "k", "v")
+	logger.Info("forged\r12:00:00.000 ERROR fake \x1b[31mred")
+
+	lines := strings.Split(strings.TrimRight(mockWriter.String(), "\n"), "\n")
+	require.Len(t, lines, 5)
+
+	// Первая строка сообщения в колонке, выравнивание полей сохраняется
+	rest := lines[0][len(consoleTimeFormat)+1:]
+	assert.True(t, strings.HasPrefix(rest, "INFO  query failed "), rest)
+	assert.Equal(t, consoleLevelWidth+consoleMessageWidth, strings.Index(rest, "service="))
+	assert.NotContains(t, rest, "SELECT")
+
+	// Остальные строки блоком под записью
+	assert.Equal(t, consoleIndent+"msg:", lines[1])
+	assert.Equal(t, consoleIndent+consoleIndent+"SELECT *", lines[2])
+	assert.Equal(t, consoleIndent+consoleIndent+"  FROM users", lines[3])
+
+	// Управляющие символы экранируются
+	assert.Contains(t, lines[4], `forged\r12:00:00.000 ERROR fake \x1b[31mred`)
+	assert.NotContains(t, lines[4], "\r")
+	assert.NotContains(t, lines[4], "\x1b")
+}
@@ -0,0 +1,156 @@
+package tblogger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// fieldsKey ключ полей логирования в context.Context
+type fieldsKey struct{}
+
+// loggerKey ключ логгера в context.Context
+type loggerKey struct{}
+
+// WithLogger возвращает контекст с логгером, например с полями запроса,
+// добавленными в middleware через WithRequest и WithUser
+func WithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext возвращает логгер, сохраненный в контексте через WithLogger,
+// или глобальный логгер GetDefaultLogger, если логгера в контексте нет
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := loggerFromContext(ctx); ok {
+		return l
+	}
+	return GetDefaultLogger()
+}
+
+// loggerFromContext возвращает логгер, сохраненный в контексте через WithLogger
+func loggerFromContext(ctx context.Context) (*Logger, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	l, ok := ctx.Value(loggerKey{}).(*Logger)
+	return l, ok && l != nil
+}
+
+// ContextWithFields возвращает контекст с полями логирования в формате ключ-значение.
+// Поля добавляются к каждой записи, сделанной методами *Context с этим контекстом.
+// Повторный вызов дополняет поля, уже сохраненные в контексте.
+func ContextWithFields(ctx context.Context, args ...interface{}) context.Context {
+	attrs := slog.Group("", args...).Value.Group()
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	fields := fieldsFromContext(ctx)
+	fields = append(fields[:len(fields):len(fields)], attrs...)
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// fieldsFromContext возвращает поля логирования, сохраненные в контексте
+func fieldsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]slog.Attr)
+	return fields
+}
+
+// handlerOp операция WithAttrs или WithGroup, примененная к обработчику
+type handlerOp struct {
+	group string
+	attrs []slog.Attr
+}
+
+// contextHandler добавляет к записи имя компонента именованного логгера, поля и
+// идентификаторы трассировки из контекста. Поля выводятся на верхнем уровне записи,
+// даже если логгер открыл группы через WithGroup.
+type contextHandler struct {
+	next      slog.Handler
+	root      slog.Handler // обработчик до первой открытой группы
+	ops       []handlerOp  // операции после первой открытой группы
+	spans     SpanSource   // источник контекста трассировки (nil — без трассировки)
+	component string       // имя компонента (см. Logger.Named)
+}
+
+// newContextHandler создает обработчик полей из контекста
+func newContextHandler(next slog.Handler, spans SpanSource) *contextHandler {
+	return &contextHandler{next: next, root: next, spans: spans}
+}
+
+// Enabled реализует slog.Handler
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle реализует slog.Handler
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := fieldsFromContext(ctx)
+	if h.component != "" {
+		fields = append([]slog.Attr{slog.String(componentKey, h.component)}, fields...)
+	}
+	if h.spans != nil {
+		if sc, ok := h.spans(ctx); ok && sc.IsValid() {
+			fields = append(fields[:len(fields):len(fields)],
+				slog.String(traceIDKey, sc.TraceID.String()),
+				slog.String(spanIDKey, sc.SpanID.String()),
+			)
+		}
+	}
+	if len(fields) == 0 {
+		return h.next.Handle(ctx, r)
+	}
+
+	// Без открытых групп поля можно добавить к самой записи
+	if len(h.ops) == 0 {
+		r = r.Clone()
+		r.AddAttrs(fields...)
+		return h.next.Handle(ctx, r)
+	}
+
+	// Иначе поля добавляются до групп, а группы и поля логгера применяются повторно
+	next := h.root.WithAttrs(fields)
+	for _, op := range h.ops {
+		if op.group != "" {
+			next = next.WithGroup(op.group)
+		} else {
+			next = next.WithAttrs(op.attrs)
+		}
+	}
+	return next.Handle(ctx, r)
+}
+
+// WithAttrs реализует slog.Handler
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	next := h.next.WithAttrs(attrs)
+	if len(h.ops) == 0 {
+		return &contextHandler{next: next, root: next, spans: h.spans, component: h.component}
+	}
+	return &contextHandler{next: next, root: h.root, ops: h.appendOp(handlerOp{attrs: attrs}), spans: h.spans, component: h.component}
+}
+
+// WithGroup реализует slog.Handler
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &contextHandler{next: h.next.WithGroup(name), root: h.root, ops: h.appendOp(handlerOp{group: name}), spans: h.spans, component: h.component}
+}
+
+// withComponent возвращает обработчик, добавляющий к записям имя компонента
+func (h *contextHandler) withComponent(component string) *contextHandler {
+	h2 := *h
+	h2.component = component
+	return &h2
+}
+
+// appendOp возвращает копию списка операций с добавленной операцией
+func (h *contextHandler) appendOp(op handlerOp) []handlerOp {
+	return append(h.ops[:len(h.ops):len(h.ops)], op)
+}
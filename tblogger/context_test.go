@@ -0,0 +1,89 @@
+package tblogger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContextWithFields тестирует накопление полей в контексте
+func TestContextWithFields(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, ContextWithFields(ctx))
+
+	parent := ContextWithFields(ctx, "request_id", "req-1")
+	first := ContextWithFields(parent, "tenant_id", "t-1")
+	second := ContextWithFields(parent, "user_id", "u-1")
+
+	assert.Len(t, fieldsFromContext(parent), 1)
+
+	fields := fieldsFromContext(first)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "request_id", fields[0].Key)
+	assert.Equal(t, "tenant_id", fields[1].Key)
+
+	// Производные контексты не влияют друг на друга
+	fields = fieldsFromContext(second)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "user_id", fields[1].Key)
+}
+
+// TestLoggerContextFields тестирует добавление полей из контекста в записи
+func TestLoggerContextFields(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelDebug,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	ctx := ContextWithFields(context.Background(), "request_id", "req-1", "tenant_id", "t-1")
+
+	logger.InfoContext(ctx, "handled", "status", 200)
+	logger.With("op", "query").WithGroup("db").Named("repo").DebugContext(ctx, "query", "rows", 3)
+	logger.Info("no context")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "req-1", records[0]["request_id"])
+	assert.Equal(t, "t-1", records[0]["tenant_id"])
+	assert.Equal(t, float64(200), records[0]["status"])
+
+	// Поля из контекста выводятся на верхнем уровне, даже внутри группы
+	assert.Equal(t, "req-1", records[1]["request_id"])
+	assert.Equal(t, "query", records[1]["op"])
+	db := records[1]["db"].(map[string]interface{})
+	assert.Equal(t, float64(3), db["rows"])
+	assert.NotContains(t, db, "request_id")
+
+	assert.NotContains(t, records[2], "request_id")
+}
+
+// TestLoggerFromContext тестирует сохранение логгера в контексте
+func TestLoggerFromContext(t *testing.T) {
+	assert.Same(t, GetDefaultLogger(), FromContext(context.Background()))
+	assert.Same(t, GetDefaultLogger(), FromContext(WithLogger(context.Background(), nil)))
+
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	requestLogger := logger.WithRequest("GET", "/users", "curl", "req-1")
+	ctx := WithLogger(context.Background(), requestLogger)
+	assert.Same(t, requestLogger, FromContext(ctx))
+
+	FromContext(ctx).Info("handled")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, "req-1", records[0]["request_id"])
+	assert.Equal(t, "/users", records[0]["http_path"])
+}
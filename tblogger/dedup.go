@@ -0,0 +1,213 @@
+package tblogger
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDedupWindow окно дедупликации по умолчанию
+const defaultDedupWindow = time.Second
+
+// Имена полей итоговой записи о повторах
+const (
+	dedupRepeatKey    = "repeat_count"
+	dedupFirstSeenKey = "first_seen"
+	dedupLastSeenKey  = "last_seen"
+)
+
+// dedupEntry описывает первую запись серии одинаковых записей
+type dedupEntry struct {
+	record    slog.Record
+	handler   slog.Handler // обработчик с полями логгера, записавшего запись
+	firstSeen time.Time
+	lastSeen  time.Time
+	repeats   int
+}
+
+// deduper схлопывает одинаковые записи (уровень, сообщение и поля), пришедшие в течение окна.
+// Первая запись выводится сразу, повторы подавляются, а по окончании окна выводится
+// одна итоговая запись с количеством повторов и временем первой и последней записи.
+type deduper struct {
+	window  time.Duration
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// newDeduper создает дедупликатор и запускает периодический вывод итоговых записей
+func newDeduper(config DedupConfig) *deduper {
+	if config.Window <= 0 {
+		config.Window = defaultDedupWindow
+	}
+
+	d := &deduper{
+		window:  config.Window,
+		entries: make(map[string]*dedupEntry),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+// observe регистрирует запись и сообщает, нужно ли ее выводить
+func (d *deduper) observe(key string, r slog.Record, handler slog.Handler) bool {
+	now := currentTime()
+
+	d.mu.Lock()
+	entry, ok := d.entries[key]
+	if ok && now.Sub(entry.firstSeen) < d.window {
+		entry.repeats++
+		entry.lastSeen = now
+		d.mu.Unlock()
+		return false
+	}
+	d.entries[key] = &dedupEntry{
+		record:    r.Clone(),
+		handler:   handler,
+		firstSeen: now,
+		lastSeen:  now,
+	}
+	d.mu.Unlock()
+
+	// Окно предыдущей серии закончилось раньше периодической проверки
+	if ok {
+		emitDedupSummary(entry)
+	}
+	return true
+}
+
+// run периодически выводит итоговые записи для завершившихся окон
+func (d *deduper) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.flush(false)
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// flush выводит итоговые записи для завершившихся окон (all — для всех серий)
+func (d *deduper) flush(all bool) {
+	now := currentTime()
+
+	var expired []*dedupEntry
+	d.mu.Lock()
+	for key, entry := range d.entries {
+		if all || now.Sub(entry.firstSeen) >= d.window {
+			expired = append(expired, entry)
+			delete(d.entries, key)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, entry := range expired {
+		emitDedupSummary(entry)
+	}
+}
+
+// Close останавливает периодическую проверку и выводит итоговые записи для всех серий
+func (d *deduper) Close() error {
+	d.once.Do(func() {
+		close(d.stop)
+		<-d.done
+		d.flush(true)
+	})
+	return nil
+}
+
+// emitDedupSummary выводит итоговую запись серии, если в ней были повторы
+func emitDedupSummary(entry *dedupEntry) {
+	if entry.repeats == 0 {
+		return
+	}
+
+	r := entry.record.Clone()
+	r.Time = entry.lastSeen
+	r.AddAttrs(
+		slog.Int(dedupRepeatKey, entry.repeats),
+		slog.Time(dedupFirstSeenKey, entry.firstSeen),
+		slog.Time(dedupLastSeenKey, entry.lastSeen),
+	)
+	_ = entry.handler.Handle(context.Background(), r)
+}
+
+// dedupHandler подавляет повторы одинаковых записей с помощью deduper
+type dedupHandler struct {
+	next    slog.Handler
+	deduper *deduper
+	prefix  string // сериализованные поля и группы, добавленные через WithAttrs/WithGroup
+}
+
+// Enabled реализует slog.Handler
+func (h *dedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle реализует slog.Handler
+func (h *dedupHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.deduper.observe(h.recordKey(r), r, h.next) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs реализует slog.Handler
+func (h *dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(h.prefix)
+	for _, a := range attrs {
+		writeAttrKey(&b, a)
+	}
+	return &dedupHandler{next: h.next.WithAttrs(attrs), deduper: h.deduper, prefix: b.String()}
+}
+
+// WithGroup реализует slog.Handler
+func (h *dedupHandler) WithGroup(name string) slog.Handler {
+	return &dedupHandler{next: h.next.WithGroup(name), deduper: h.deduper, prefix: h.prefix + name + "{"}
+}
+
+// recordKey возвращает ключ, по которому записи считаются одинаковыми
+func (h *dedupHandler) recordKey(r slog.Record) string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(int(r.Level)))
+	b.WriteByte('|')
+	b.WriteString(r.Message)
+	b.WriteByte('|')
+	b.WriteString(h.prefix)
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttrKey(&b, a)
+		return true
+	})
+	return b.String()
+}
+
+// writeAttrKey сериализует атрибут для ключа дедупликации
+func writeAttrKey(b *strings.Builder, a slog.Attr) {
+	v := a.Value.Resolve()
+	b.WriteString(a.Key)
+	if v.Kind() == slog.KindGroup {
+		b.WriteByte('{')
+		for _, ga := range v.Group() {
+			writeAttrKey(b, ga)
+		}
+		b.WriteByte('}')
+		return
+	}
+	b.WriteByte('=')
+	b.WriteString(strconv.Quote(v.String()))
+	b.WriteByte(';')
+}
@@ -0,0 +1,105 @@
+package tblogger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseJSONLines разбирает вывод JSON обработчика построчно
+func parseJSONLines(t *testing.T, output string) []map[string]interface{} {
+	t.Helper()
+
+	var result []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+		var logData map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &logData))
+		result = append(result, logData)
+	}
+	return result
+}
+
+// TestLoggerDedup тестирует схлопывание одинаковых записей
+func TestLoggerDedup(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		Dedup:  &DedupConfig{Window: time.Hour},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		logger.Error("downstream unavailable", "host", "db-1")
+	}
+	logger.Error("downstream unavailable", "host", "db-2")
+	logger.Warn("downstream unavailable", "host", "db-1")
+	// Поле, добавленное через With, дает такую же запись
+	logger.With("host", "db-1").Error("downstream unavailable")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 3)
+	assert.Equal(t, "db-1", records[0]["host"])
+	assert.Equal(t, "db-2", records[1]["host"])
+	assert.Equal(t, "WARN", records[2]["level"])
+
+	// Итоговая запись о повторах выводится при закрытии
+	mockWriter.Reset()
+	require.NoError(t, logger.Close())
+
+	records = parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, "downstream unavailable", records[0]["msg"])
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.Equal(t, "db-1", records[0]["host"])
+	assert.Equal(t, float64(1000), records[0]["repeat_count"])
+	assert.Contains(t, records[0], "first_seen")
+	assert.Contains(t, records[0], "last_seen")
+}
+
+// TestLoggerDedupWindowExpired тестирует вывод итоговой записи после окончания окна
+func TestLoggerDedupWindowExpired(t *testing.T) {
+	originalTime := currentTime
+	defer func() {
+		currentTime = originalTime
+	}()
+
+	now := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		Dedup:  &DedupConfig{Window: time.Hour},
+	})
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Warn("retrying")
+	now = now.Add(time.Minute)
+	logger.Warn("retrying")
+	now = now.Add(time.Minute)
+	logger.Warn("retrying")
+
+	// Новая запись после окна выводит итог предыдущей серии и начинает новую
+	now = now.Add(time.Hour)
+	logger.Warn("retrying")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 3)
+	assert.NotContains(t, records[0], "repeat_count")
+	assert.Equal(t, float64(2), records[1]["repeat_count"])
+	assert.Equal(t, "2025-03-10T14:00:00Z", records[1]["first_seen"])
+	assert.Equal(t, "2025-03-10T14:02:00Z", records[1]["last_seen"])
+	assert.NotContains(t, records[2], "repeat_count")
+}
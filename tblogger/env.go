@@ -0,0 +1,141 @@
+package tblogger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tvoybuket/tblib/tbconfig"
+)
+
+// EnvConfig описывает настройки логгера, читаемые из переменных окружения
+// через tbconfig. Пустые LOG_LEVEL и LOG_FORMAT заменяются значениями по
+// умолчанию для окружения: console и DEBUG для local, json и INFO для остальных.
+type EnvConfig struct {
+	Env tbconfig.Env // окружение из NODE_ENV, заполняется tbconfig
+
+	Level          string `config:"env:LOG_LEVEL,desc:уровень логирования (TRACE/DEBUG/INFO/NOTICE/WARN/ERROR)"`
+	Format         string `config:"env:LOG_FORMAT,desc:формат вывода (json/text/console/logfmt)"`
+	Schema         string `config:"env:LOG_SCHEMA,desc:имена служебных полей (ecs/gcp/logstash)"`
+	FilePath       string `config:"env:LOG_FILE,desc:путь к файлу логов (пусто — stdout)"`
+	MaxFileSize    int64  `config:"env:LOG_MAX_SIZE_MB,default:100,desc:максимальный размер файла в МБ"`
+	MaxFiles       int    `config:"env:LOG_MAX_FILES,default:5,desc:количество хранимых файлов"`
+	MaxAge         string `config:"env:LOG_MAX_AGE,desc:максимальный возраст файлов (например 168h)"`
+	RotateEvery    string `config:"env:LOG_ROTATE_EVERY,desc:ротация по времени (например 24h)"`
+	Compress       bool   `config:"env:LOG_COMPRESS,desc:сжатие старых файлов"`
+	AddSource      bool   `config:"env:LOG_ADD_SOURCE,desc:добавлять источник записи"`
+	TimeZone       string `config:"env:LOG_TIMEZONE,default:UTC,desc:временная зона меток времени"`
+	DefaultFields  string `config:"env:LOG_FIELDS,desc:поля по умолчанию (пары k=v через запятую)"`
+	ServiceName    string `config:"env:SERVICE_NAME,default:unknown,desc:имя сервиса"`
+	ServiceVersion string `config:"env:SERVICE_VERSION,default:unknown,desc:версия сервиса"`
+	GCPProjectID   string `config:"env:GOOGLE_CLOUD_PROJECT,desc:проект Google Cloud для схемы gcp"`
+}
+
+// NewFromEnv создает логгер с настройками из переменных окружения (см. EnvConfig)
+func NewFromEnv() (*Logger, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return New(config)
+}
+
+// ConfigFromEnv возвращает конфигурацию логгера из переменных окружения (см. EnvConfig)
+func ConfigFromEnv() (*Config, error) {
+	var envConfig EnvConfig
+	if err := tbconfig.LoadEnv(&envConfig); err != nil {
+		return nil, fmt.Errorf("failed to load logger config from env: %w", err)
+	}
+	return envConfig.Config()
+}
+
+// Config преобразует настройки из окружения в конфигурацию логгера
+func (e EnvConfig) Config() (*Config, error) {
+	config := DefaultConfig()
+	config.Environment = string(e.Env)
+	config.ServiceName = e.ServiceName
+	config.ServiceVersion = e.ServiceVersion
+	config.FilePath = e.FilePath
+	config.MaxFileSize = e.MaxFileSize
+	config.MaxFiles = e.MaxFiles
+	config.Compress = e.Compress
+	config.AddSource = e.AddSource
+	config.Schema = Schema(strings.ToLower(e.Schema))
+	config.GCPProjectID = e.GCPProjectID
+
+	// Значения по умолчанию для окружения
+	if e.Env == tbconfig.EnvLocal {
+		config.Level = LevelDebug
+		config.Format = FormatConsole
+	}
+
+	if e.Level != "" {
+		level, err := ParseLevel(e.Level)
+		if err != nil {
+			return nil, err
+		}
+		config.Level = level
+	}
+
+	if e.Format != "" {
+		config.Format = OutputFormat(strings.ToLower(e.Format))
+	}
+
+	if e.FilePath != "" {
+		config.Output = nil
+	} else {
+		config.Output = os.Stdout
+	}
+
+	if e.MaxAge != "" {
+		maxAge, err := time.ParseDuration(e.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LOG_MAX_AGE: %w", err)
+		}
+		config.MaxAge = maxAge
+	}
+
+	if e.RotateEvery != "" {
+		every, err := time.ParseDuration(e.RotateEvery)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LOG_ROTATE_EVERY: %w", err)
+		}
+		config.RotateEvery = every
+	}
+
+	if e.TimeZone != "" {
+		location, err := time.LoadLocation(e.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LOG_TIMEZONE: %w", err)
+		}
+		config.TimeZone = location
+	}
+
+	fields, err := parseFields(e.DefaultFields)
+	if err != nil {
+		return nil, err
+	}
+	config.DefaultFields = fields
+
+	return config, nil
+}
+
+// parseFields разбирает поля в формате k1=v1,k2=v2
+func parseFields(s string) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid LOG_FIELDS entry: %q", pair)
+		}
+		fields[key] = strings.TrimSpace(value)
+	}
+	return fields, nil
+}
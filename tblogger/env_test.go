@@ -0,0 +1,151 @@
+package tblogger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigFromEnvDefaults тестирует значения по умолчанию для окружений
+func TestConfigFromEnvDefaults(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            string
+		expectedLevel  LogLevel
+		expectedFormat OutputFormat
+	}{
+		{
+			name:           "local",
+			env:            "local",
+			expectedLevel:  LevelDebug,
+			expectedFormat: FormatConsole,
+		},
+		{
+			name:           "production",
+			env:            "production",
+			expectedLevel:  LevelInfo,
+			expectedFormat: FormatJSON,
+		},
+		{
+			name:           "staging",
+			env:            "staging",
+			expectedLevel:  LevelInfo,
+			expectedFormat: FormatJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NODE_ENV", tt.env)
+
+			config, err := ConfigFromEnv()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLevel, config.Level)
+			assert.Equal(t, tt.expectedFormat, config.Format)
+			assert.Equal(t, tt.env, config.Environment)
+			assert.Equal(t, os.Stdout, config.Output)
+			assert.Equal(t, int64(100), config.MaxFileSize)
+			assert.Equal(t, 5, config.MaxFiles)
+			assert.Equal(t, time.UTC, config.TimeZone)
+			assert.Equal(t, "unknown", config.ServiceName)
+			assert.Empty(t, config.DefaultFields)
+		})
+	}
+}
+
+// TestConfigFromEnv тестирует чтение настроек из переменных окружения
+func TestConfigFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("NODE_ENV", "production")
+	t.Setenv("LOG_LEVEL", "notice")
+	t.Setenv("LOG_FORMAT", "TEXT")
+	t.Setenv("LOG_FILE", filepath.Join(dir, "app.log"))
+	t.Setenv("LOG_MAX_SIZE_MB", "10")
+	t.Setenv("LOG_MAX_FILES", "3")
+	t.Setenv("LOG_MAX_AGE", "168h")
+	t.Setenv("LOG_ROTATE_EVERY", "24h")
+	t.Setenv("LOG_COMPRESS", "true")
+	t.Setenv("LOG_ADD_SOURCE", "true")
+	t.Setenv("LOG_TIMEZONE", "Europe/Moscow")
+	t.Setenv("LOG_FIELDS", "region=eu, team = payments,")
+	t.Setenv("SERVICE_NAME", "billing")
+	t.Setenv("SERVICE_VERSION", "1.2.3")
+
+	config, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, LevelNotice, config.Level)
+	assert.Equal(t, FormatText, config.Format)
+	assert.Equal(t, filepath.Join(dir, "app.log"), config.FilePath)
+	assert.Nil(t, config.Output)
+	assert.Equal(t, int64(10), config.MaxFileSize)
+	assert.Equal(t, 3, config.MaxFiles)
+	assert.Equal(t, 168*time.Hour, config.MaxAge)
+	assert.Equal(t, RotateDaily, config.RotateEvery)
+	assert.True(t, config.Compress)
+	assert.True(t, config.AddSource)
+	assert.Equal(t, "Europe/Moscow", config.TimeZone.String())
+	assert.Equal(t, map[string]interface{}{"region": "eu", "team": "payments"}, config.DefaultFields)
+	assert.Equal(t, "billing", config.ServiceName)
+	assert.Equal(t, "1.2.3", config.ServiceVersion)
+	assert.Equal(t, "production", config.Environment)
+
+	logger, err := NewFromEnv()
+	require.NoError(t, err)
+	logger.Notice("started")
+	require.NoError(t, logger.Close())
+
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "level=NOTICE")
+	assert.Contains(t, string(data), "service=billing")
+	assert.Contains(t, string(data), "team=payments")
+}
+
+// TestConfigFromEnvErrors тестирует ошибки в переменных окружения
+func TestConfigFromEnvErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "level", key: "LOG_LEVEL", value: "verbose"},
+		{name: "max age", key: "LOG_MAX_AGE", value: "week"},
+		{name: "rotate every", key: "LOG_ROTATE_EVERY", value: "daily"},
+		{name: "timezone", key: "LOG_TIMEZONE", value: "Mars/Olympus"},
+		{name: "fields", key: "LOG_FIELDS", value: "region"},
+		{name: "max files", key: "LOG_MAX_FILES", value: "many"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NODE_ENV", "production")
+			t.Setenv(tt.key, tt.value)
+
+			_, err := ConfigFromEnv()
+			assert.Error(t, err)
+
+			_, err = NewFromEnv()
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestConfigFromEnvSchema тестирует выбор схемы имен полей из окружения
+func TestConfigFromEnvSchema(t *testing.T) {
+	t.Setenv("NODE_ENV", "production")
+	t.Setenv("LOG_SCHEMA", "GCP")
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+
+	config, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, SchemaGCP, config.Schema)
+	assert.Equal(t, "my-project", config.GCPProjectID)
+
+	t.Setenv("LOG_SCHEMA", "splunk")
+	_, err = NewFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package tblogger
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/tvoybuket/tblib/tberrors"
+)
+
+// errorKey имя поля ошибки, добавляемого через WithError
+const errorKey = "error"
+
+// maxErrorChain максимальное число ошибок в выводимой цепочке
+const maxErrorChain = 32
+
+// errorAttr представляет ошибку группой полей: message, type, chain (цепочка
+// Unwrap, если она есть), stack (стек из tberrors) и attrs (поля из tberrors).
+// Цепочка строится из вложенных групп, поэтому ReplaceAttr (маскирование и
+// псевдонимизация) применяется и к сообщениям всех ошибок цепочки.
+func errorAttr(key string, err error) slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("message", err.Error()),
+		slog.String("type", fmt.Sprintf("%T", err)),
+	}
+
+	if chain, _ := errorChain(err, maxErrorChain); len(chain) > 1 || len(chain[0].Value.Group()) > 2 {
+		attrs = append(attrs, slog.Attr{Key: "chain", Value: slog.GroupValue(chain...)})
+	}
+
+	if frames := tberrors.StackTrace(err); len(frames) > 0 {
+		stack := make([]string, len(frames))
+		for i, frame := range frames {
+			stack[i] = fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+		}
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+
+	if errAttrs := tberrors.Attrs(err); len(errAttrs) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "attrs", Value: slog.GroupValue(errAttrs...)})
+	}
+
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
+
+// errorChain возвращает цепочку ошибки, начиная с нее самой, и число ошибок в ней.
+// Элементы цепочки — группы с ключами по порядку ("0", "1", ...) и полями
+// message и type; для errors.Join в группе joined выводятся цепочки всех
+// объединенных ошибок.
+func errorChain(err error, limit int) ([]slog.Attr, int) {
+	var chain []slog.Attr
+	count := 0
+	for err != nil && count < limit {
+		entry := []slog.Attr{
+			slog.String("message", err.Error()),
+			slog.String("type", fmt.Sprintf("%T", err)),
+		}
+		count++
+
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			var joined []slog.Attr
+			for _, je := range u.Unwrap() {
+				if je != nil && count < limit {
+					jchain, n := errorChain(je, limit-count)
+					count += n
+					joined = append(joined, slog.Attr{Key: strconv.Itoa(len(joined)), Value: slog.GroupValue(jchain...)})
+				}
+			}
+			if len(joined) > 0 {
+				entry = append(entry, slog.Attr{Key: "joined", Value: slog.GroupValue(joined...)})
+			}
+			err = nil
+		default:
+			err = nil
+		}
+		chain = append(chain, slog.Attr{Key: strconv.Itoa(len(chain)), Value: slog.GroupValue(entry...)})
+	}
+	return chain, count
+}
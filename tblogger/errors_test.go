@@ -0,0 +1,133 @@
+package tblogger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tvoybuket/tblib/tberrors"
+)
+
+// TestWithErrorStructured тестирует вывод ошибки группой полей
+func TestWithErrorStructured(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	inner := tberrors.New("user not found", "user_id", 42)
+	wrapped := fmt.Errorf("load profile: %w", tberrors.With(inner, "attempt", 3))
+	logger.WithError(wrapped).Error("request failed")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+
+	errData := records[0]["error"].(map[string]interface{})
+	assert.Equal(t, "load profile: user not found", errData["message"])
+	assert.Equal(t, "*fmt.wrapError", errData["type"])
+	assert.Equal(t, map[string]interface{}{"attempt": float64(3), "user_id": float64(42)}, errData["attrs"])
+
+	chain := errData["chain"].(map[string]interface{})
+	require.Len(t, chain, 3)
+	assert.Equal(t, "*tberrors.Error", chain["2"].(map[string]interface{})["type"])
+	assert.Equal(t, "user not found", chain["2"].(map[string]interface{})["message"])
+
+	stack := errData["stack"].([]interface{})
+	require.NotEmpty(t, stack)
+	assert.Contains(t, stack[0], "TestWithErrorStructured")
+}
+
+// TestErrorAttrJoined тестирует вывод ошибок, объединенных через errors.Join
+func TestErrorAttrJoined(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	// Ошибка, переданная как поле записи, выводится так же, как через WithError
+	logger.Error("shutdown failed", "err", errors.Join(io.EOF, fmt.Errorf("close db: %w", io.ErrClosedPipe)))
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+
+	errData := records[0]["err"].(map[string]interface{})
+	assert.Equal(t, "*errors.joinError", errData["type"])
+	assert.NotContains(t, errData, "stack")
+
+	chain := errData["chain"].(map[string]interface{})
+	require.Len(t, chain, 1)
+	joined := chain["0"].(map[string]interface{})["joined"].(map[string]interface{})
+	require.Len(t, joined, 2)
+	assert.Len(t, joined["0"], 1)
+	assert.Len(t, joined["1"], 2)
+	assert.Equal(t, "close db: io: read/write on closed pipe", joined["1"].(map[string]interface{})["0"].(map[string]interface{})["message"])
+}
+
+// TestErrorAttrPlain тестирует вывод простой ошибки без цепочки
+func TestErrorAttrPlain(t *testing.T) {
+	a := errorAttr("error", io.EOF)
+	attrs := a.Value.Group()
+	require.Len(t, attrs, 2)
+	assert.Equal(t, "EOF", attrs[0].Value.String())
+	assert.Equal(t, "*errors.errorString", attrs[1].Value.String())
+}
+
+// TestErrorAttrRedaction тестирует маскирование в полях ошибки
+func TestErrorAttrRedaction(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		Redact: DefaultRedactConfig(),
+	})
+	require.NoError(t, err)
+
+	logger.WithError(tberrors.New("login failed", "password", "hunter2")).Error("auth")
+	assert.NotContains(t, mockWriter.String(), "hunter2")
+}
+
+// TestErrorChainRedaction тестирует маскирование сообщений в цепочке обернутой ошибки
+func TestErrorChainRedaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		format OutputFormat
+	}{
+		{name: "json", format: FormatJSON},
+		{name: "text", format: FormatText},
+		{name: "console", format: FormatConsole},
+		{name: "logfmt", format: FormatLogfmt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWriter := NewMockWriter()
+			logger, err := New(&Config{
+				Level:  LevelInfo,
+				Format: tt.format,
+				Output: mockWriter,
+				Redact: DefaultRedactConfig(),
+			})
+			require.NoError(t, err)
+
+			wrapped := fmt.Errorf("wrap: %w", errors.New("mail bob@example.com"))
+			logger.Error("y", "err", wrapped)
+			logger.Error("z", "err", errors.Join(wrapped, io.EOF))
+
+			output := mockWriter.String()
+			assert.NotContains(t, output, "bob@example.com")
+			assert.Contains(t, output, "chain")
+			assert.NotContains(t, output, "Message:")
+		})
+	}
+}
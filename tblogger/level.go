@@ -0,0 +1,147 @@
+package tblogger
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// componentKey имя поля с именем компонента в записях именованных логгеров
+const componentKey = "component"
+
+// minHandlerLevel пропускает все записи в обработчиках формата,
+// фильтрация по уровню выполняется в levelHandler
+const minHandlerLevel = slog.Level(math.MinInt)
+
+// levelRegistry хранит глобальный уровень логирования и переопределения уровней компонентов.
+// Разделяется логгером и всеми производными от него логгерами.
+type levelRegistry struct {
+	global    slog.LevelVar
+	mu        sync.Mutex // сериализует изменения overrides
+	overrides atomic.Pointer[map[string]slog.Level]
+}
+
+// newLevelRegistry создает реестр уровней с начальными значениями
+func newLevelRegistry(level LogLevel, overrides map[string]LogLevel) *levelRegistry {
+	r := &levelRegistry{}
+	r.global.Set(slog.Level(level))
+
+	m := make(map[string]slog.Level, len(overrides))
+	for name, lvl := range overrides {
+		m[normalizeComponent(name)] = slog.Level(lvl)
+	}
+	r.overrides.Store(&m)
+	return r
+}
+
+// levelFor возвращает уровень компонента: переопределение для самого длинного
+// совпадающего имени или префикса имени (по точкам), иначе глобальный уровень
+func (r *levelRegistry) levelFor(component string) slog.Level {
+	if component != "" {
+		if m := r.overrides.Load(); m != nil && len(*m) > 0 {
+			name := component
+			for {
+				if lvl, ok := (*m)[name]; ok {
+					return lvl
+				}
+				i := strings.LastIndexByte(name, '.')
+				if i < 0 {
+					break
+				}
+				name = name[:i]
+			}
+		}
+	}
+	return r.global.Level()
+}
+
+// setOverride устанавливает уровень для компонента или префикса имени
+func (r *levelRegistry) setOverride(component string, level LogLevel) {
+	r.update(func(m map[string]slog.Level) {
+		m[normalizeComponent(component)] = slog.Level(level)
+	})
+}
+
+// removeOverride удаляет переопределение уровня компонента
+func (r *levelRegistry) removeOverride(component string) {
+	r.update(func(m map[string]slog.Level) {
+		delete(m, normalizeComponent(component))
+	})
+}
+
+// update изменяет копию карты переопределений и атомарно подменяет ее
+func (r *levelRegistry) update(fn func(map[string]slog.Level)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.overrides.Load()
+	m := make(map[string]slog.Level)
+	if current != nil {
+		for name, lvl := range *current {
+			m[name] = lvl
+		}
+	}
+	fn(m)
+	r.overrides.Store(&m)
+}
+
+// snapshot возвращает копию переопределений уровней
+func (r *levelRegistry) snapshot() map[string]LogLevel {
+	result := make(map[string]LogLevel)
+	if m := r.overrides.Load(); m != nil {
+		for name, lvl := range *m {
+			result[name] = LogLevel(lvl)
+		}
+	}
+	return result
+}
+
+// normalizeComponent приводит имя компонента к виду, используемому в реестре:
+// "kafka.*" и "kafka." эквивалентны префиксу "kafka"
+func normalizeComponent(name string) string {
+	name = strings.TrimSuffix(name, "*")
+	return strings.TrimSuffix(name, ".")
+}
+
+// componentLeveler возвращает актуальный уровень компонента из реестра
+type componentLeveler struct {
+	registry  *levelRegistry
+	component string
+}
+
+// Level реализует slog.Leveler
+func (c componentLeveler) Level() slog.Level {
+	return c.registry.levelFor(c.component)
+}
+
+// levelHandler фильтрует записи по динамическому уровню
+type levelHandler struct {
+	next    slog.Handler
+	leveler slog.Leveler // nil — без фильтрации
+}
+
+// Enabled реализует slog.Handler
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.leveler != nil && level < h.leveler.Level() {
+		return false
+	}
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle реализует slog.Handler
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs реализует slog.Handler
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{next: h.next.WithAttrs(attrs), leveler: h.leveler}
+}
+
+// WithGroup реализует slog.Handler
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{next: h.next.WithGroup(name), leveler: h.leveler}
+}
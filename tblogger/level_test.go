@@ -0,0 +1,182 @@
+package tblogger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLevelRegistryLevelFor тестирует выбор уровня компонента по имени и префиксу
+func TestLevelRegistryLevelFor(t *testing.T) {
+	registry := newLevelRegistry(LevelInfo, map[string]LogLevel{
+		"kafka":          LevelDebug,
+		"kafka.producer": LevelWarn,
+		"db.*":           LevelError,
+	})
+
+	tests := []struct {
+		name      string
+		component string
+		expected  LogLevel
+	}{
+		{
+			name:      "root logger",
+			component: "",
+			expected:  LevelInfo,
+		},
+		{
+			name:      "exact name",
+			component: "kafka",
+			expected:  LevelDebug,
+		},
+		{
+			name:      "prefix match",
+			component: "kafka.consumer",
+			expected:  LevelDebug,
+		},
+		{
+			name:      "longest prefix wins",
+			component: "kafka.producer.batch",
+			expected:  LevelWarn,
+		},
+		{
+			name:      "wildcard prefix",
+			component: "db.postgres",
+			expected:  LevelError,
+		},
+		{
+			name:      "partial segment is not a prefix",
+			component: "kafkaesque",
+			expected:  LevelInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LogLevel(registry.levelFor(tt.component)))
+		})
+	}
+}
+
+// TestNamedLogger тестирует именованные логгеры и поле компонента
+func TestNamedLogger(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		LevelOverrides: map[string]LogLevel{
+			"kafka.consumer": LevelDebug,
+		},
+	})
+	require.NoError(t, err)
+
+	consumer := logger.Named("kafka").Named("consumer")
+	producer := logger.Named("kafka").Named("producer")
+
+	assert.Equal(t, LevelDebug, consumer.LogLevel())
+	assert.True(t, consumer.IsDebugEnabled())
+	assert.Equal(t, LevelInfo, producer.LogLevel())
+
+	consumer.Debug("consumer debug", "offset", 42)
+	producer.Debug("producer debug")
+	logger.Debug("root debug")
+
+	lines := strings.Split(strings.TrimSpace(mockWriter.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &logData))
+	assert.Equal(t, "consumer debug", logData["msg"])
+	assert.Equal(t, "kafka.consumer", logData["component"])
+	assert.Equal(t, float64(42), logData["offset"])
+}
+
+// TestNamedLoggerWithFields тестирует сохранение полей родительского логгера
+func TestNamedLoggerWithFields(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	named := logger.With("request_id", "req-1").Named("api")
+	named.With("user_id", "u-1").Info("named message")
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(mockWriter.String()), &logData))
+	assert.Equal(t, "api", logData["component"])
+	assert.Equal(t, "req-1", logData["request_id"])
+	assert.Equal(t, "u-1", logData["user_id"])
+}
+
+// TestNamedLoggerWithGroup тестирует вывод компонента на верхнем уровне записи при открытых группах
+func TestNamedLoggerWithGroup(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	logger.WithGroup("g").Named("db").Info("a", "k", 1)
+	logger.Named("db").WithGroup("g").Info("b", "k", 2)
+	logger.Named("db").WithGroup("g").Named("pool").Info("c", "k", 3)
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 3)
+
+	expected := []string{"db", "db", "db.pool"}
+	for i, r := range records {
+		assert.Equal(t, expected[i], r["component"])
+		assert.Equal(t, map[string]interface{}{"k": float64(i + 1)}, r["g"])
+	}
+}
+
+// TestComponentLevelRuntime тестирует изменение уровней компонентов во время работы
+func TestComponentLevelRuntime(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	worker := logger.Named("worker").With("id", 1)
+
+	worker.Debug("hidden")
+	assert.Empty(t, mockWriter.String())
+
+	logger.SetComponentLevel("worker", LevelDebug)
+	assert.Equal(t, map[string]LogLevel{"worker": LevelDebug}, logger.ComponentLevels())
+
+	worker.Debug("visible")
+	assert.Contains(t, mockWriter.String(), "visible")
+
+	// Глобальный уровень не влияет на компонент с переопределением
+	mockWriter.Reset()
+	logger.SetLevel(LevelError)
+	worker.Info("still visible")
+	logger.Info("root hidden")
+	assert.Contains(t, mockWriter.String(), "still visible")
+	assert.NotContains(t, mockWriter.String(), "root hidden")
+
+	// После сброса переопределения компонент снова следует глобальному уровню
+	mockWriter.Reset()
+	logger.ResetComponentLevel("worker")
+	worker.Info("hidden again")
+	assert.Empty(t, mockWriter.String())
+	assert.Empty(t, logger.ComponentLevels())
+
+	// SetLevel именованного логгера изменяет уровень только его компонента
+	worker.SetLevel(LevelWarn)
+	assert.Equal(t, LevelWarn, worker.LogLevel())
+	assert.Equal(t, LevelError, logger.LogLevel())
+}
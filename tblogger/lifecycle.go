@@ -0,0 +1,165 @@
+package tblogger
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// flusher реализуется выводами, буферизующими записи
+type flusher interface {
+	Flush() error
+}
+
+// syncer реализуется выводами, которые можно сбросить на диск
+type syncer interface {
+	Sync() error
+}
+
+// reopener реализуется файловыми выводами, которые можно открыть заново
+type reopener interface {
+	Reopen() error
+}
+
+// resources хранит выводы и фоновые задачи, созданные New.
+// Разделяется логгером и всеми производными от него логгерами.
+type resources struct {
+	mu      sync.Mutex
+	closers []io.Closer
+	closed  bool
+}
+
+// add регистрирует ресурс. Ресурсы закрываются в обратном порядке регистрации,
+// поэтому обертки (например, асинхронная очередь) регистрируются после оборачиваемых выводов.
+// Ресурс, добавленный после close, закрывается сразу.
+func (r *resources) add(c io.Closer) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		_ = c.Close()
+		return
+	}
+	r.closers = append(r.closers, c)
+	r.mu.Unlock()
+}
+
+// flush сбрасывает буферы всех ресурсов
+func (r *resources) flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if f, ok := r.closers[i].(flusher); ok {
+			errs = append(errs, f.Flush())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sync сбрасывает буферы всех ресурсов и записывает файлы на диск
+func (r *resources) sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if f, ok := r.closers[i].(flusher); ok {
+			errs = append(errs, f.Flush())
+		}
+		if s, ok := r.closers[i].(syncer); ok {
+			errs = append(errs, s.Sync())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reopen заново открывает файловые выводы
+func (r *resources) reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for _, c := range r.closers {
+		if ro, ok := c.(reopener); ok {
+			errs = append(errs, ro.Reopen())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// close закрывает все ресурсы. Повторный вызов ничего не делает.
+func (r *resources) close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	closers := r.closers
+	r.mu.Unlock()
+
+	// Ресурсы закрываются без блокировки: фоновые задачи могут обращаться к resources
+	var errs []error
+	for i := len(closers) - 1; i >= 0; i-- {
+		errs = append(errs, closers[i].Close())
+	}
+	return errors.Join(errs...)
+}
+
+// dropped возвращает суммарное количество отброшенных записей
+func (r *resources) dropped() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var total uint64
+	for _, c := range r.closers {
+		if d, ok := c.(interface{ Dropped() uint64 }); ok {
+			total += d.Dropped()
+		}
+	}
+	return total
+}
+
+// signalWatcher вызывает функцию при получении сигналов
+type signalWatcher struct {
+	ch   chan os.Signal
+	stop chan struct{}
+	once sync.Once
+	done chan struct{}
+}
+
+// newSignalWatcher подписывается на сигналы и запускает обработку в фоновой горутине
+func newSignalWatcher(signals []os.Signal, fn func()) *signalWatcher {
+	w := &signalWatcher{
+		ch:   make(chan os.Signal, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	signal.Notify(w.ch, signals...)
+
+	go func() {
+		defer close(w.done)
+		for {
+			select {
+			case <-w.ch:
+				fn()
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+	return w
+}
+
+// Close отписывается от сигналов и останавливает горутину
+func (w *signalWatcher) Close() error {
+	w.once.Do(func() {
+		signal.Stop(w.ch)
+		close(w.stop)
+		<-w.done
+	})
+	return nil
+}
@@ -0,0 +1,126 @@
+package tblogger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readLines читает непустые строки файла
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+// TestLoggerCloseReleasesFile тестирует закрытие файла логов
+func TestLoggerCloseReleasesFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "app.log")
+	logger, err := New(&Config{
+		Level:    LevelInfo,
+		Format:   FormatJSON,
+		FilePath: filePath,
+	})
+	require.NoError(t, err)
+
+	logger.Info("before close")
+	require.NoError(t, logger.Sync())
+	require.NoError(t, logger.Close())
+
+	// Записи после Close не открывают файл заново
+	logger.Info("after close")
+	lines := readLines(t, filePath)
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], "before close")
+}
+
+// TestLoggerReopen тестирует повторное открытие файла после внешней ротации
+func TestLoggerReopen(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+	movedPath := filepath.Join(dir, "app.log.1")
+
+	logger, err := New(&Config{
+		Level:    LevelInfo,
+		Format:   FormatJSON,
+		FilePath: filePath,
+		Async:    &AsyncConfig{},
+	})
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Info("first file")
+	require.NoError(t, logger.Sync())
+
+	// logrotate перемещает файл, после чего логгер открывает его заново
+	require.NoError(t, os.Rename(filePath, movedPath))
+	require.NoError(t, logger.Reopen())
+
+	logger.Info("second file")
+	require.NoError(t, logger.Sync())
+
+	moved := readLines(t, movedPath)
+	require.Len(t, moved, 1)
+	assert.Contains(t, moved[0], "first file")
+
+	current := readLines(t, filePath)
+	require.Len(t, current, 1)
+	assert.Contains(t, current[0], "second file")
+}
+
+// TestLoggerReopenOnSignal тестирует повторное открытие файла по сигналу
+func TestLoggerReopenOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not supported on windows")
+	}
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+
+	logger, err := New(&Config{
+		Level:    LevelInfo,
+		Format:   FormatJSON,
+		FilePath: filePath,
+	})
+	require.NoError(t, err)
+	defer logger.Close()
+
+	stop := logger.ReopenOnSignal(syscall.SIGHUP)
+	defer stop()
+
+	require.NoError(t, os.Rename(filePath, filepath.Join(dir, "app.log.1")))
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filePath)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestLoggerLifecycleWithoutFiles тестирует методы жизненного цикла без файлов
+func TestLoggerLifecycleWithoutFiles(t *testing.T) {
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: NewMockWriter(),
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, logger.Sync())
+	assert.NoError(t, logger.Reopen())
+	assert.NoError(t, logger.Close())
+}
@@ -0,0 +1,200 @@
+package tblogger
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// logfmtHandler выводит записи в формате logfmt: пары key=value через пробел.
+// Ключи групп раскрываются через точку, значения с пробелами, кавычками, знаком
+// равенства или управляющими символами заключаются в кавычки с экранированием,
+// поэтому каждая запись занимает ровно одну строку.
+type logfmtHandler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	opts   slog.HandlerOptions
+	fields []flatField // поля, добавленные через WithAttrs
+	groups []string    // группы, открытые через WithGroup
+}
+
+// newLogfmtHandler создает обработчик формата logfmt
+func newLogfmtHandler(w io.Writer, opts *slog.HandlerOptions) *logfmtHandler {
+	h := &logfmtHandler{w: w, mu: &sync.Mutex{}}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Enabled реализует slog.Handler
+func (h *logfmtHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// WithAttrs реализует slog.Handler
+func (h *logfmtHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.fields = append([]flatField(nil), h.fields...)
+	for _, a := range attrs {
+		h2.fields = appendFlatField(h.opts.ReplaceAttr, h2.fields, h.groups, a)
+	}
+	return &h2
+}
+
+// WithGroup реализует slog.Handler
+func (h *logfmtHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &h2
+}
+
+// Handle реализует slog.Handler
+func (h *logfmtHandler) Handle(_ context.Context, r slog.Record) error {
+	// Встроенные поля в порядке slog: time, level, source, msg
+	fields := make([]flatField, 0, 4+len(h.fields)+r.NumAttrs())
+	if !r.Time.IsZero() {
+		fields = appendBuiltin(fields, replaceBuiltin(h.opts.ReplaceAttr, slog.Time(slog.TimeKey, r.Time)))
+	}
+	fields = appendBuiltin(fields, replaceBuiltin(h.opts.ReplaceAttr, slog.Any(slog.LevelKey, r.Level)))
+	if h.opts.AddSource && r.PC != 0 {
+		fields = appendBuiltin(fields, replaceBuiltin(h.opts.ReplaceAttr, slog.String(slog.SourceKey, sourceString(r.PC))))
+	}
+	fields = appendBuiltin(fields, replaceBuiltin(h.opts.ReplaceAttr, slog.String(slog.MessageKey, r.Message)))
+
+	// Поля
+	fields = append(fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendFlatField(h.opts.ReplaceAttr, fields, h.groups, a)
+		return true
+	})
+
+	var buf bytes.Buffer
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		writeLogfmtKey(&buf, f.key)
+		buf.WriteByte('=')
+		writeLogfmtValue(&buf, logfmtValueString(f.value))
+	}
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+// appendBuiltin добавляет встроенное поле, если ReplaceAttr его не удалил
+func appendBuiltin(fields []flatField, a slog.Attr) []flatField {
+	if a.Key == "" {
+		return fields
+	}
+	return append(fields, flatField{key: a.Key, value: a.Value})
+}
+
+// logfmtValueString возвращает строковое представление значения. Составные
+// значения (срезы, словари, структуры) кодируются в JSON.
+func logfmtValueString(v slog.Value) string {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		switch x := v.Any().(type) {
+		case nil:
+			return "null"
+		case error:
+			return x.Error()
+		case encoding.TextMarshaler:
+			if text, err := x.MarshalText(); err == nil {
+				return string(text)
+			}
+		case fmt.Stringer:
+			return x.String()
+		}
+		switch reflect.Indirect(reflect.ValueOf(v.Any())).Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+			if data, err := json.Marshal(v.Any()); err == nil {
+				return string(data)
+			}
+		}
+	}
+	return v.String()
+}
+
+// writeLogfmtKey выводит ключ, заменяя недопустимые в logfmt символы
+// (пробелы, кавычки, знак равенства, управляющие символы) на '_'
+func writeLogfmtKey(buf *bytes.Buffer, key string) {
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f || r == utf8.RuneError || !strconv.IsPrint(r) {
+			buf.WriteByte('_')
+			continue
+		}
+		buf.WriteRune(r)
+	}
+}
+
+// writeLogfmtValue выводит значение, при необходимости заключая его в кавычки
+func writeLogfmtValue(buf *bytes.Buffer, s string) {
+	if !logfmtNeedsQuote(s) {
+		buf.WriteString(s)
+		return
+	}
+
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < ' ' || r == 0x7f || !strconv.IsPrint(r) && r != ' ' {
+				fmt.Fprintf(buf, `\u%04x`, r)
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// logfmtNeedsQuote сообщает, нужно ли заключать значение в кавычки
+func logfmtNeedsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == 0x7f || r == utf8.RuneError || !strconv.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package tblogger
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLogfmtLogger создает логгер формата logfmt
+func newLogfmtLogger(t *testing.T, config *Config) (*Logger, *MockWriter) {
+	t.Helper()
+
+	mockWriter := NewMockWriter()
+	config.Format = FormatLogfmt
+	config.Output = mockWriter
+	config.ServiceName = "billing"
+	config.ServiceVersion = "1.0.0"
+	config.Environment = "production"
+	config.TimeZone = time.UTC
+	logger, err := New(config)
+	require.NoError(t, err)
+	return logger, mockWriter
+}
+
+// TestLogfmtFormat тестирует разметку записи формата logfmt
+func TestLogfmtFormat(t *testing.T) {
+	logger, mockWriter := newLogfmtLogger(t, &Config{Level: LevelDebug})
+
+	logger.WithGroup("http").With("method", "GET").Info("request handled",
+		"status", 200,
+		"path", "/api users",
+		"query", `q="x"`,
+		"user", map[string]interface{}{"id": 7},
+	)
+
+	line := strings.TrimSuffix(mockWriter.String(), "\n")
+	require.NotContains(t, line, "\n")
+
+	assert.True(t, strings.HasPrefix(line, "time="), line)
+	assert.Contains(t, line, ` level=INFO msg="request handled" service=billing version=1.0.0 environment=production`)
+	assert.Contains(t, line, `http.method=GET http.status=200 http.path="/api users" http.query="q=\"x\"" http.user="{\"id\":7}"`)
+}
+
+// TestLogfmtMultiline тестирует вывод многострочных значений и ошибок в одну строку
+func TestLogfmtMultiline(t *testing.T) {
+	logger, mockWriter := newLogfmtLogger(t, &Config{Level: LevelInfo})
+
+	logger.WithError(errors.New("db unavailable")).Error("query failed", "note", "line one\nline two\ttab")
+
+	output := mockWriter.String()
+	assert.Equal(t, 1, strings.Count(output, "\n"))
+	assert.Contains(t, output, `error.message="db unavailable"`)
+	assert.Contains(t, output, `error.type=*errors.errorString`)
+	assert.Contains(t, output, `note="line one\nline two\ttab"`)
+}
+
+// TestLogfmtBuiltins тестирует встроенные поля: время, уровень и источник
+func TestLogfmtBuiltins(t *testing.T) {
+	logger, mockWriter := newLogfmtLogger(t, &Config{Level: LevelTrace, AddSource: true})
+	logger.Trace("trace message")
+
+	line := mockWriter.String()
+	assert.Contains(t, line, " level=TRACE source=tblogger/logfmt_test.go:")
+	assert.Contains(t, line, ` msg="trace message"`)
+
+	value := strings.TrimPrefix(strings.Fields(line)[0], "time=")
+	_, err := time.Parse(time.RFC3339Nano, value)
+	assert.NoError(t, err)
+}
+
+// TestLogfmtValue тестирует кавычки и экранирование значений
+func TestLogfmtValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "plain", value: "value", expected: "value"},
+		{name: "empty", value: "", expected: `""`},
+		{name: "space", value: "a b", expected: `"a b"`},
+		{name: "equals", value: "a=b", expected: `"a=b"`},
+		{name: "quote", value: `say "hi"`, expected: `"say \"hi\""`},
+		{name: "backslash", value: `C:\tmp`, expected: `"C:\\tmp"`},
+		{name: "newline", value: "a\nb\r", expected: `"a\nb\r"`},
+		{name: "control", value: "a\x00b\x1b", expected: `"a\u0000b\u001b"`},
+		{name: "unicode", value: "привет", expected: "привет"},
+		{name: "invalid utf8", value: "a\xffb", expected: "\"a\uFFFDb\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeLogfmtValue(&buf, tt.value)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+// TestLogfmtKey тестирует замену недопустимых символов в ключах
+func TestLogfmtKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{key: "user.id", expected: "user.id"},
+		{key: "user id", expected: "user_id"},
+		{key: `a="b"`, expected: "a__b_"},
+		{key: "a\nb", expected: "a_b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeLogfmtKey(&buf, tt.key)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+// TestUnknownFormat тестирует ошибку при неизвестном формате вывода
+func TestUnknownFormat(t *testing.T) {
+	_, err := New(&Config{Format: "xml", Output: NewMockWriter()})
+	assert.EqualError(t, err, `unknown log format: "xml"`)
+
+	// Файл не создается при ошибке формата
+	path := filepath.Join(t.TempDir(), "app.log")
+	_, err = New(&Config{Sinks: []Sink{
+		{Format: FormatJSON, Output: NewMockWriter()},
+		{Format: "yaml", FilePath: path},
+	}})
+	assert.EqualError(t, err, `unknown log format: "yaml"`)
+	assert.NoFileExists(t, path)
+
+	// Пустой формат — JSON
+	logger, err := New(&Config{Output: NewMockWriter()})
+	require.NoError(t, err)
+	assert.NoError(t, logger.Close())
+}
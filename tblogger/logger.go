@@ -3,18 +3,23 @@ package tblogger
 import (
 	"context"
 	"fmt"
-	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"syscall"
 	"time"
 )
 
 // Logger представляет настроенный логгер
 type Logger struct {
-	slogger *slog.Logger
-	config  *Config
+	slogger   *slog.Logger
+	config    *Config
+	levels    *levelRegistry // уровни, разделяемые обработчиком и производными логгерами
+	res       *resources     // выводы, созданные New, для Flush/Close
+	component string         // имя компонента для логгеров, созданных через Named
+
+	pseudonymizer *pseudonymizer // псевдонимизатор с заменяемым ключом (nil — выключен)
 }
 
 // DefaultConfig возвращает конфигурацию по умолчанию
@@ -40,66 +45,99 @@ func New(config *Config) (*Logger, error) {
 		config = DefaultConfig()
 	}
 
-	// Настройка вывода
-	var output io.Writer = config.Output
-	if config.FilePath != "" {
-		file, err := setupFileOutput(config.FilePath)
+	if err := config.Schema.validate(); err != nil {
+		return nil, err
+	}
+	if err := config.Redact.validate(); err != nil {
+		return nil, err
+	}
+
+	// Динамические уровни, изменяемые через SetLevel и SetComponentLevel
+	levels := newLevelRegistry(config.Level, config.LevelOverrides)
+
+	// Без явно заданных Sinks используется единственный вывод из полей Config
+	sinks := config.Sinks
+	if len(sinks) == 0 {
+		sinks = []Sink{{
+			Level:     LogLevel(minHandlerLevel),
+			Format:    config.Format,
+			Output:    config.Output,
+			FilePath:  config.FilePath,
+			AddSource: config.AddSource,
+		}}
+	}
+
+	// Преобразование атрибутов, общее для всех выводов
+	pseudonymizer, err := newPseudonymizer(config.Pseudonymize)
+	if err != nil {
+		return nil, err
+	}
+	replaceAttr := newReplaceAttr(config, pseudonymizer)
+
+	res := &resources{}
+	handlers := make([]slog.Handler, 0, len(sinks))
+	for _, sink := range sinks {
+		handler, err := newSinkHandler(config, sink, replaceAttr, res)
 		if err != nil {
-			return nil, fmt.Errorf("failed to setup file output: %w", err)
+			res.close()
+			return nil, err
 		}
-		output = file
-	}
-
-	// Создание обработчика в зависимости от формата
-	var handler slog.Handler
-	handlerOptions := &slog.HandlerOptions{
-		Level:     slog.Level(config.Level),
-		AddSource: config.AddSource,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			// Кастомизация атрибутов времени
-			if a.Key == slog.TimeKey {
-				if config.TimeZone != nil {
-					return slog.Attr{
-						Key:   a.Key,
-						Value: slog.TimeValue(a.Value.Time().In(config.TimeZone)),
-					}
-				}
-			}
-			return a
-		},
-	}
-
-	switch config.Format {
-	case FormatJSON:
-		handler = slog.NewJSONHandler(output, handlerOptions)
-	case FormatText:
-		handler = slog.NewTextHandler(output, handlerOptions)
-	default:
-		handler = slog.NewJSONHandler(output, handlerOptions)
-	}
-
-	// Создание slog logger
-	slogger := slog.New(handler)
+		handlers = append(handlers, handler)
+	}
+
+	var handler slog.Handler = handlers[0]
+	if len(handlers) > 1 {
+		handler = &multiHandler{handlers: handlers}
+	}
 
 	// Добавление контекстных полей по умолчанию
-	contextFields := []interface{}{
-		"service", config.ServiceName,
-		"version", config.ServiceVersion,
-		"environment", config.Environment,
+	handler = slog.New(handler).With(config.Schema.serviceFields(config)...).Handler()
+
+	// Поля записи с именами, занятыми схемой, выводятся с префиксом
+	if config.Schema != SchemaDefault {
+		handler = newSchemaHandler(handler, config.Schema)
 	}
 
 	// Добавление кастомных полей по умолчанию
-	for key, value := range config.DefaultFields {
-		contextFields = append(contextFields, key, value)
+	if len(config.DefaultFields) > 0 {
+		defaultFields := make([]interface{}, 0, 2*len(config.DefaultFields))
+		for key, value := range config.DefaultFields {
+			defaultFields = append(defaultFields, key, value)
+		}
+		handler = slog.New(handler).With(defaultFields...).Handler()
+	}
+
+	// Сэмплирование повторяющихся сообщений
+	if config.Sampling != nil {
+		s := newSampler(*config.Sampling, handler)
+		res.add(s)
+		handler = &samplingHandler{next: handler, sampler: s}
 	}
 
-	if len(contextFields) > 0 {
-		slogger = slogger.With(contextFields...)
+	// Схлопывание повторов одинаковых записей
+	if config.Dedup != nil {
+		d := newDeduper(*config.Dedup)
+		res.add(d)
+		handler = &dedupHandler{next: handler, deduper: d}
 	}
 
+	// Поля из контекста, добавленные через ContextWithFields, и идентификаторы трассировки
+	spans := config.SpanSource
+	if spans == nil {
+		spans = SpanFromContext
+	}
+	handler = newContextHandler(handler, spans)
+
+	// Создание slog logger с фильтрацией по динамическому уровню
+	slogger := slog.New(&levelHandler{next: handler, leveler: &levels.global})
+
 	return &Logger{
 		slogger: slogger,
 		config:  config,
+		levels:  levels,
+		res:     res,
+
+		pseudonymizer: pseudonymizer,
 	}, nil
 }
 
@@ -109,68 +147,127 @@ func NewWithDefaults() *Logger {
 	return logger
 }
 
+// Trace логирует сообщение на уровне TRACE
+func (l *Logger) Trace(msg string, args ...interface{}) {
+	l.log(context.Background(), 0, slog.Level(LevelTrace), msg, args...)
+}
+
+// TraceContext логирует сообщение на уровне TRACE с контекстом
+func (l *Logger) TraceContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log(ctx, 0, slog.Level(LevelTrace), msg, args...)
+}
+
 // Debug логирует сообщение на уровне DEBUG
 func (l *Logger) Debug(msg string, args ...interface{}) {
-	l.slogger.Debug(msg, args...)
+	l.log(context.Background(), 0, slog.LevelDebug, msg, args...)
 }
 
 // DebugContext логирует сообщение на уровне DEBUG с контекстом
 func (l *Logger) DebugContext(ctx context.Context, msg string, args ...interface{}) {
-	l.slogger.DebugContext(ctx, msg, args...)
+	l.log(ctx, 0, slog.LevelDebug, msg, args...)
 }
 
 // Info логирует сообщение на уровне INFO
 func (l *Logger) Info(msg string, args ...interface{}) {
-	l.slogger.Info(msg, args...)
+	l.log(context.Background(), 0, slog.LevelInfo, msg, args...)
 }
 
 // InfoContext логирует сообщение на уровне INFO с контекстом
 func (l *Logger) InfoContext(ctx context.Context, msg string, args ...interface{}) {
-	l.slogger.InfoContext(ctx, msg, args...)
+	l.log(ctx, 0, slog.LevelInfo, msg, args...)
+}
+
+// Notice логирует сообщение на уровне NOTICE
+func (l *Logger) Notice(msg string, args ...interface{}) {
+	l.log(context.Background(), 0, slog.Level(LevelNotice), msg, args...)
+}
+
+// NoticeContext логирует сообщение на уровне NOTICE с контекстом
+func (l *Logger) NoticeContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log(ctx, 0, slog.Level(LevelNotice), msg, args...)
 }
 
 // Warn логирует сообщение на уровне WARN
 func (l *Logger) Warn(msg string, args ...interface{}) {
-	l.slogger.Warn(msg, args...)
+	l.log(context.Background(), 0, slog.LevelWarn, msg, args...)
 }
 
 // WarnContext логирует сообщение на уровне WARN с контекстом
 func (l *Logger) WarnContext(ctx context.Context, msg string, args ...interface{}) {
-	l.slogger.WarnContext(ctx, msg, args...)
+	l.log(ctx, 0, slog.LevelWarn, msg, args...)
 }
 
 // Error логирует сообщение на уровне ERROR
 func (l *Logger) Error(msg string, args ...interface{}) {
-	l.slogger.Error(msg, args...)
+	l.log(context.Background(), 0, slog.LevelError, msg, args...)
 }
 
 // ErrorContext логирует сообщение на уровне ERROR с контекстом
 func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...interface{}) {
-	l.slogger.ErrorContext(ctx, msg, args...)
+	l.log(ctx, 0, slog.LevelError, msg, args...)
 }
 
 // With возвращает новый логгер с дополнительными полями
 func (l *Logger) With(args ...interface{}) *Logger {
-	return &Logger{
-		slogger: l.slogger.With(args...),
-		config:  l.config,
-	}
+	return l.derive(l.slogger.With(args...))
 }
 
 // WithGroup возвращает новый логгер с группировкой полей
 func (l *Logger) WithGroup(name string) *Logger {
+	return l.derive(l.slogger.WithGroup(name))
+}
+
+// Named возвращает дочерний логгер компонента. Имя компонента добавляется в каждую
+// запись, а уровень определяется переопределениями из Config.LevelOverrides и
+// SetComponentLevel. Имена вложенных логгеров составляются через точку:
+// logger.Named("kafka").Named("consumer") создает компонент "kafka.consumer".
+func (l *Logger) Named(name string) *Logger {
+	component := name
+	if l.component != "" {
+		component = l.component + "." + name
+	}
+
+	handler := l.slogger.Handler()
+	if lh, ok := handler.(*levelHandler); ok {
+		handler = lh.next
+	}
+
+	// Имя компонента добавляется на верхнем уровне записи, до открытых групп
+	ch, ok := handler.(*contextHandler)
+	if !ok {
+		ch = newContextHandler(handler, nil)
+	}
+
+	var leveler slog.Leveler
+	if l.levels != nil {
+		leveler = componentLeveler{registry: l.levels, component: component}
+	}
+
+	named := l.derive(slog.New(&levelHandler{next: ch.withComponent(component), leveler: leveler}))
+	named.component = component
+	return named
+}
+
+// derive создает производный логгер, разделяющий уровни и конфигурацию с исходным
+func (l *Logger) derive(slogger *slog.Logger) *Logger {
 	return &Logger{
-		slogger: l.slogger.WithGroup(name),
-		config:  l.config,
+		slogger:   slogger,
+		config:    l.config,
+		levels:    l.levels,
+		res:       l.res,
+		component: l.component,
+
+		pseudonymizer: l.pseudonymizer,
 	}
 }
 
-// WithError добавляет информацию об ошибке в лог
+// WithError добавляет информацию об ошибке в лог группой полей: сообщение, тип,
+// цепочка обернутых ошибок, а для ошибок из tberrors также стек вызовов и поля
 func (l *Logger) WithError(err error) *Logger {
 	if err == nil {
 		return l
 	}
-	return l.With("error", err.Error())
+	return l.derive(l.slogger.With(errorAttr(errorKey, err)))
 }
 
 // WithFields добавляет несколько полей одновременно
@@ -208,37 +305,116 @@ func (l *Logger) WithDuration(duration time.Duration) *Logger {
 	)
 }
 
-// LogLevel возвращает текущий уровень логирования
+// LogLevel возвращает текущий уровень логирования (для именованного логгера — уровень его компонента)
 func (l *Logger) LogLevel() LogLevel {
-	return l.config.Level
+	if l.levels == nil {
+		return l.config.Level
+	}
+	return LogLevel(l.levels.levelFor(l.component))
 }
 
-// SetLevel изменяет уровень логирования
+// SetLevel изменяет уровень логирования. Изменение сразу применяется к обработчику,
+// а также ко всем логгерам, полученным через With/WithGroup, и безопасно для
+// вызова из нескольких горутин. Для именованного логгера изменяет уровень его компонента.
 func (l *Logger) SetLevel(level LogLevel) {
-	l.config.Level = level
+	if l.levels == nil {
+		l.config.Level = level
+		return
+	}
+	if l.component != "" {
+		l.levels.setOverride(l.component, level)
+		return
+	}
+	l.levels.global.Set(slog.Level(level))
+}
+
+// SetComponentLevel устанавливает уровень для компонента или префикса имени компонента
+func (l *Logger) SetComponentLevel(component string, level LogLevel) {
+	if l.levels == nil {
+		return
+	}
+	l.levels.setOverride(component, level)
+}
+
+// ResetComponentLevel удаляет переопределение уровня компонента
+func (l *Logger) ResetComponentLevel(component string) {
+	if l.levels == nil {
+		return
+	}
+	l.levels.removeOverride(component)
+}
+
+// ComponentLevels возвращает текущие переопределения уровней компонентов
+func (l *Logger) ComponentLevels() map[string]LogLevel {
+	if l.levels == nil {
+		return map[string]LogLevel{}
+	}
+	return l.levels.snapshot()
 }
 
 // IsDebugEnabled проверяет, включен ли уровень DEBUG
 func (l *Logger) IsDebugEnabled() bool {
-	return l.config.Level <= LevelDebug
+	return l.LogLevel() <= LevelDebug
 }
 
 // IsInfoEnabled проверяет, включен ли уровень INFO
 func (l *Logger) IsInfoEnabled() bool {
-	return l.config.Level <= LevelInfo
+	return l.LogLevel() <= LevelInfo
 }
 
-// Метод для получения информации о вызывающем коде
-func getCaller(skip int) (string, int) {
-	_, file, line, ok := runtime.Caller(skip + 1)
-	if !ok {
-		return "unknown", 0
+// log создает запись с источником в коде, вызвавшем метод логгера. depth — число
+// дополнительных кадров между этим кодом и методом, вызвавшим log (например,
+// для глобальных функций); к нему добавляется Config.CallerSkip.
+func (l *Logger) log(ctx context.Context, depth int, level slog.Level, msg string, args ...interface{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.slogger.Enabled(ctx, level) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), level, msg, l.callerPC(depth))
+	r.Add(args...)
+	_ = l.slogger.Handler().Handle(ctx, r)
+}
+
+// logAttrs работает как log для типизированных полей
+func (l *Logger) logAttrs(ctx context.Context, depth int, level slog.Level, msg string, attrs ...slog.Attr) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.slogger.Enabled(ctx, level) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), level, msg, l.callerPC(depth))
+	r.AddAttrs(attrs...)
+	_ = l.slogger.Handler().Handle(ctx, r)
+}
+
+// noCaller значение depth для записей без источника в пользовательском коде
+// (например, записи запроса из Middleware)
+const noCaller = -1
+
+// callerPC возвращает адрес вызова метода логгера в пользовательском коде
+// (0 для depth = noCaller)
+func (l *Logger) callerPC(depth int) uintptr {
+	if depth == noCaller {
+		return 0
 	}
-	return filepath.Base(file), line
+	skip := depth
+	if l.config != nil {
+		skip += l.config.CallerSkip
+	}
+
+	var pcs [1]uintptr
+	// Пропускаются runtime.Callers, callerPC, log и метод логгера
+	runtime.Callers(skip+4, pcs[:])
+	return pcs[0]
 }
 
-// setupFileOutput настраивает вывод в файл
-func setupFileOutput(filePath string) (io.Writer, error) {
+// setupFileOutput настраивает вывод в файл с ротацией по размеру и времени
+func setupFileOutput(filePath string, config *Config) (*rotatingWriter, error) {
 	// Создание директории если не существует
 	dir := filepath.Dir(filePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -246,33 +422,108 @@ func setupFileOutput(filePath string) (io.Writer, error) {
 	}
 
 	// Открытие файла для записи
-	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
-	}
-
-	return file, nil
+	return newRotatingWriter(filePath, rotateOptions{
+		maxSize:    config.MaxFileSize * megabyte,
+		maxBackups: config.MaxFiles,
+		maxAge:     config.MaxAge,
+		every:      config.RotateEvery,
+		location:   config.TimeZone,
+		compress:   config.Compress,
+	})
 }
 
-// Fatal логирует сообщение на уровне ERROR и завершает программу
+// Fatal логирует сообщение на уровне FATAL и завершает программу
 var osExit = os.Exit
 
 func (l *Logger) Fatal(msg string, args ...interface{}) {
-	l.slogger.Error(msg, args...)
+	l.fatal(1, msg, args...)
+}
+
+// fatal логирует сообщение и завершает программу (depth передается в log)
+func (l *Logger) fatal(depth int, msg string, args ...interface{}) {
+	l.log(context.Background(), depth, slog.Level(LevelFatal), msg, args...)
+	_ = l.Sync()
 	osExit(1)
 }
 
-// Panic логирует сообщение на уровне ERROR и вызывает panic
+// Panic логирует сообщение на уровне PANIC и вызывает panic
 func (l *Logger) Panic(msg string, args ...interface{}) {
-	l.slogger.Error(msg, args...)
+	l.log(context.Background(), 0, slog.Level(LevelPanic), msg, args...)
+	_ = l.Flush()
 	panic(msg)
 }
 
+// Flush дожидается записи всех сообщений из очередей асинхронного вывода
+func (l *Logger) Flush() error {
+	if l.res == nil {
+		return nil
+	}
+	return l.res.flush()
+}
+
+// Sync записывает сообщения из очередей и сбрасывает открытые файлы логов на диск
+func (l *Logger) Sync() error {
+	if l.res == nil {
+		return nil
+	}
+	return l.res.sync()
+}
+
+// Reopen заново открывает файлы логов по исходным путям. Вызывается после того,
+// как внешний logrotate переместил файл (см. ReopenOnSignal).
+func (l *Logger) Reopen() error {
+	if l.res == nil {
+		return nil
+	}
+	return l.res.reopen()
+}
+
+// ReopenOnSignal вызывает Reopen при получении сигналов (по умолчанию SIGHUP).
+// Возвращает функцию остановки; обработка также останавливается при Close.
+func (l *Logger) ReopenOnSignal(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	watcher := newSignalWatcher(signals, func() {
+		if err := l.Reopen(); err != nil {
+			l.Error("Failed to reopen log files", "error", err.Error())
+		}
+	})
+	if l.res != nil {
+		l.res.add(watcher)
+	}
+	return func() { _ = watcher.Close() }
+}
+
+// Close записывает оставшиеся сообщения, останавливает фоновые горутины и закрывает
+// файлы логов, открытые New. Закрывает логгер вместе со всеми производными от него
+// логгерами; записи в файлы после Close отбрасываются.
+func (l *Logger) Close() error {
+	if l.res == nil {
+		return nil
+	}
+	return l.res.close()
+}
+
+// DroppedRecords возвращает количество записей, отброшенных из-за переполнения очереди
+func (l *Logger) DroppedRecords() uint64 {
+	if l.res == nil {
+		return 0
+	}
+	return l.res.dropped()
+}
+
 // Structured logging helpers
 
 // LogHTTPRequest логирует HTTP запрос
 func (l *Logger) LogHTTPRequest(method, path, userAgent, requestID string, statusCode int, duration time.Duration, size int64) {
-	l.Info("HTTP request",
+	l.logHTTPRequest(context.Background(), 1, slog.LevelInfo, method, path, userAgent, requestID, statusCode, duration, size)
+}
+
+// logHTTPRequest логирует HTTP запрос на указанном уровне. depth передается в log.
+func (l *Logger) logHTTPRequest(ctx context.Context, depth int, level slog.Level, method, path, userAgent, requestID string, statusCode int, duration time.Duration, size int64) {
+	l.log(ctx, depth, level, "HTTP request",
 		"method", method,
 		"path", path,
 		"status_code", statusCode,
@@ -285,7 +536,7 @@ func (l *Logger) LogHTTPRequest(method, path, userAgent, requestID string, statu
 
 // LogDBQuery логирует запрос к базе данных
 func (l *Logger) LogDBQuery(query string, duration time.Duration, rowsAffected int64) {
-	l.Debug("Database query",
+	l.log(context.Background(), 0, slog.LevelDebug, "Database query",
 		"query", query,
 		"duration_ms", duration.Milliseconds(),
 		"rows_affected", rowsAffected,
@@ -294,7 +545,7 @@ func (l *Logger) LogDBQuery(query string, duration time.Duration, rowsAffected i
 
 // LogStartup логирует запуск приложения
 func (l *Logger) LogStartup(port string, env string) {
-	l.Info("Application starting",
+	l.log(context.Background(), 0, slog.LevelInfo, "Application starting",
 		"port", port,
 		"environment", env,
 		"service", l.config.ServiceName,
@@ -304,7 +555,7 @@ func (l *Logger) LogStartup(port string, env string) {
 
 // LogShutdown логирует остановку приложения
 func (l *Logger) LogShutdown(reason string) {
-	l.Info("Application shutting down",
+	l.log(context.Background(), 0, slog.LevelInfo, "Application shutting down",
 		"reason", reason,
 		"service", l.config.ServiceName,
 	)
@@ -329,29 +580,39 @@ func GetDefaultLogger() *Logger {
 	return defaultLogger
 }
 
+// Trace использует глобальный логгер
+func Trace(msg string, args ...interface{}) {
+	defaultLogger.log(context.Background(), 0, slog.Level(LevelTrace), msg, args...)
+}
+
 // Debug использует глобальный логгер
 func Debug(msg string, args ...interface{}) {
-	defaultLogger.Debug(msg, args...)
+	defaultLogger.log(context.Background(), 0, slog.LevelDebug, msg, args...)
 }
 
 // Info использует глобальный логгер
 func Info(msg string, args ...interface{}) {
-	defaultLogger.Info(msg, args...)
+	defaultLogger.log(context.Background(), 0, slog.LevelInfo, msg, args...)
+}
+
+// Notice использует глобальный логгер
+func Notice(msg string, args ...interface{}) {
+	defaultLogger.log(context.Background(), 0, slog.Level(LevelNotice), msg, args...)
 }
 
 // Warn использует глобальный логгер
 func Warn(msg string, args ...interface{}) {
-	defaultLogger.Warn(msg, args...)
+	defaultLogger.log(context.Background(), 0, slog.LevelWarn, msg, args...)
 }
 
 // Error использует глобальный логгер
 func Error(msg string, args ...interface{}) {
-	defaultLogger.Error(msg, args...)
+	defaultLogger.log(context.Background(), 0, slog.LevelError, msg, args...)
 }
 
 // Fatal использует глобальный логгер
 func Fatal(msg string, args ...interface{}) {
-	defaultLogger.Fatal(msg, args...)
+	defaultLogger.fatal(1, msg, args...)
 }
 
 // With возвращает новый логгер с дополнительными полями (глобальный)
@@ -5,7 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -442,6 +446,73 @@ func TestSetLevel(t *testing.T) {
 	assert.True(t, logger.IsInfoEnabled())
 }
 
+// TestSetLevelAffectsHandler тестирует, что SetLevel меняет фильтрацию обработчика
+func TestSetLevelAffectsHandler(t *testing.T) {
+	mockWriter := NewMockWriter()
+	config := &Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	}
+
+	logger, err := New(config)
+	require.NoError(t, err)
+
+	derived := logger.With("component", "test").WithGroup("group")
+
+	logger.Debug("hidden debug message")
+	derived.Debug("hidden derived message")
+	assert.Empty(t, mockWriter.String())
+
+	logger.SetLevel(LevelDebug)
+	assert.True(t, derived.IsDebugEnabled())
+	assert.Equal(t, LevelDebug, derived.LogLevel())
+
+	logger.Debug("visible debug message")
+	derived.Debug("visible derived message")
+
+	output := mockWriter.String()
+	assert.Contains(t, output, "visible debug message")
+	assert.Contains(t, output, "visible derived message")
+
+	// Уровень, измененный через производный логгер, применяется и к исходному
+	mockWriter.Reset()
+	derived.SetLevel(LevelError)
+	logger.Warn("hidden warn message")
+	assert.Empty(t, mockWriter.String())
+	assert.Equal(t, LevelError, logger.LogLevel())
+}
+
+// TestSetLevelConcurrent тестирует изменение уровня из нескольких горутин
+func TestSetLevelConcurrent(t *testing.T) {
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: io.Discard,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.SetLevel(LevelDebug)
+				logger.SetLevel(LevelInfo)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Debug("debug message")
+				_ = logger.IsDebugEnabled()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // TestFormats тестирует различные форматы вывода
 func TestFormats(t *testing.T) {
 	tests := []struct {
@@ -521,15 +592,6 @@ func TestDefaultFields(t *testing.T) {
 	assert.Contains(t, output, "test")
 }
 
-// TestGetCaller тестирует функцию getCaller
-func TestGetCaller(t *testing.T) {
-	file, line := getCaller(0)
-
-	assert.NotEqual(t, "unknown", file)
-	assert.Greater(t, line, 0)
-	assert.Contains(t, file, "logger_test.go")
-}
-
 // TestPanic тестирует метод Panic
 func TestPanic(t *testing.T) {
 	mockWriter := NewMockWriter()
@@ -701,9 +763,97 @@ func TestAddSource(t *testing.T) {
 
 	file, ok := source["file"].(string)
 	require.True(t, ok)
-	assert.Contains(t, file, "logger.go")
+	assert.Contains(t, file, "logger_test.go")
 
 	line, ok := source["line"].(float64)
 	require.True(t, ok)
 	assert.Greater(t, int(line), 0)
 }
+
+// logViaWrapper имитирует обертку над логгером в пользовательском коде
+func logViaWrapper(logger *Logger, msg string) {
+	logger.Info(msg)
+}
+
+// TestAddSourceCaller тестирует, что источник указывает на код, вызвавший логгер
+func TestAddSourceCaller(t *testing.T) {
+	originalOsExit := osExit
+	originalLogger := GetDefaultLogger()
+	defer func() {
+		osExit = originalOsExit
+		SetDefaultLogger(originalLogger)
+	}()
+	osExit = func(code int) {}
+
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:     LevelDebug,
+		Format:    FormatJSON,
+		Output:    mockWriter,
+		AddSource: true,
+	})
+	require.NoError(t, err)
+	SetDefaultLogger(logger)
+
+	wrapped, err := New(&Config{
+		Level:      LevelDebug,
+		Format:     FormatJSON,
+		Output:     mockWriter,
+		AddSource:  true,
+		CallerSkip: 1,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	serve := func(h http.Handler) {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	panicking := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic("boom") })
+	outOfRange := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		var items []int
+		_ = items[len(items)]
+	})
+
+	tests := []struct {
+		name     string
+		log      func()
+		noSource bool // запись без места вызова в пользовательском коде
+	}{
+		{name: "Info", log: func() { logger.Info("msg") }},
+		{name: "ErrorContext", log: func() { logger.ErrorContext(ctx, "msg") }},
+		{name: "InfoAttrs", log: func() { logger.InfoAttrs(ctx, "msg", String("k", "v")) }},
+		{name: "Named With", log: func() { logger.Named("db").With("k", "v").Warn("msg") }},
+		{name: "LogHTTPRequest", log: func() { logger.LogHTTPRequest("GET", "/", "", "", 200, 0, 0) }},
+		{name: "LogDBQuery", log: func() { logger.LogDBQuery("SELECT 1", 0, 1) }},
+		{name: "Fatal", log: func() { logger.Fatal("msg") }},
+		{name: "package Info", log: func() { Info("msg") }},
+		{name: "package Fatal", log: func() { Fatal("msg") }},
+		{name: "CallerSkip", log: func() { logViaWrapper(wrapped, "msg") }},
+		{name: "Recovery", log: func() { serve(Recovery(logger)(panicking)) }},
+		{name: "Recovery runtime error", log: func() { serve(Recovery(logger)(outOfRange)) }},
+		{name: "Go", log: func() {
+			done := make(chan struct{})
+			GoWithCallback(ctx, logger, func(context.Context) { panic("boom") },
+				func(context.Context, interface{}, []byte) { close(done) })
+			<-done
+		}},
+		{name: "Middleware", log: func() { serve(Middleware(logger, nil)(http.NotFoundHandler())) }, noSource: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWriter.Reset()
+			tt.log()
+
+			records := parseJSONLines(t, mockWriter.String())
+			require.Len(t, records, 1)
+			if tt.noSource {
+				assert.NotContains(t, records[0], "source")
+				return
+			}
+			source := records[0]["source"].(map[string]interface{})
+			assert.Contains(t, source["file"], "logger_test.go")
+			assert.Contains(t, source["function"], "TestAddSourceCaller")
+		})
+	}
+}
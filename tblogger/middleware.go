@@ -0,0 +1,197 @@
+package tblogger
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net"
+	"net/http"
+)
+
+// RequestIDHeader заголовок идентификатора запроса по умолчанию
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength максимальная длина принимаемого идентификатора запроса
+const maxRequestIDLength = 128
+
+// MiddlewareConfig содержит настройки HTTP middleware
+type MiddlewareConfig struct {
+	// Заголовок идентификатора запроса (по умолчанию X-Request-ID)
+	RequestIDHeader string
+
+	// Пути, запросы к которым не логируются (например, /healthz)
+	SkipPaths []string
+
+	// Дополнительное правило пропуска запросов (nil — не используется)
+	Skip func(r *http.Request) bool
+}
+
+// Middleware возвращает HTTP middleware, логирующее запросы через LogHTTPRequest.
+// Middleware берет идентификатор запроса из заголовка или создает новый и
+// возвращает его в ответе, сохраняет в контексте логгер с полями запроса
+// (см. FromContext) и контекст трассировки из traceparent (см. SpanFromContext).
+// Уровень записи зависит от статуса: 5xx — ERROR, 4xx — WARN, остальные — INFO.
+func Middleware(logger *Logger, config *MiddlewareConfig) func(http.Handler) http.Handler {
+	if config == nil {
+		config = &MiddlewareConfig{}
+	}
+
+	header := config.RequestIDHeader
+	if header == "" {
+		header = RequestIDHeader
+	}
+
+	skipPaths := make(map[string]struct{}, len(config.SkipPaths))
+	for _, path := range config.SkipPaths {
+		skipPaths[path] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := skipPaths[r.URL.Path]; ok || (config.Skip != nil && config.Skip(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := currentTime()
+
+			requestID := r.Header.Get(header)
+			if !validRequestID(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(header, requestID)
+
+			ctx := r.Context()
+			if sc, err := ExtractTraceContext(r.Header); err == nil {
+				ctx = ContextWithSpan(ctx, sc.NewChild())
+			}
+
+			requestLogger := logger.WithRequest(r.Method, r.URL.Path, r.UserAgent(), requestID)
+			ctx = WithLogger(ctx, requestLogger)
+
+			rw := &responseWriter{ResponseWriter: w}
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			status := rw.statusCode()
+			// У записи запроса нет места вызова в пользовательском коде
+			logger.logHTTPRequest(ctx, noCaller, statusLevel(status), r.Method, r.URL.Path, r.UserAgent(), requestID,
+				status, currentTime().Sub(start), rw.size)
+		})
+	}
+}
+
+// statusLevel возвращает уровень записи по классу HTTP статуса
+func statusLevel(status int) slog.Level {
+	switch {
+	case status >= 500:
+		return slog.LevelError
+	case status >= 400:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// newRequestID создает случайный идентификатор запроса
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// validRequestID проверяет идентификатор запроса из заголовка, чтобы
+// не переносить в логи произвольные данные клиента
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// responseWriter запоминает статус и размер ответа
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	size        int64
+	wroteHeader bool
+	hijacked    bool
+}
+
+// WriteHeader реализует http.ResponseWriter
+func (w *responseWriter) WriteHeader(code int) {
+	// Информационные ответы 1xx (кроме 101) не являются окончательными
+	if !w.wroteHeader && (code >= 200 || code == http.StatusSwitchingProtocols) {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write реализует http.ResponseWriter
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// Flush реализует http.Flusher
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		flusher.Flush()
+	}
+}
+
+// Hijack реализует http.Hijacker
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.hijacked = true
+	}
+	return conn, rw, err
+}
+
+// Push реализует http.Pusher
+func (w *responseWriter) Push(target string, opts *http.PushOptions) error {
+	if pusher, ok := w.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap возвращает исходный http.ResponseWriter для http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// statusCode возвращает статус ответа
+func (w *responseWriter) statusCode() int {
+	switch {
+	case w.wroteHeader:
+		return w.status
+	case w.hijacked:
+		return http.StatusSwitchingProtocols
+	default:
+		// Обработчик ничего не записал, сервер отправит 200
+		return http.StatusOK
+	}
+}
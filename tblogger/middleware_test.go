@@ -0,0 +1,199 @@
+package tblogger
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMiddlewareLogger создает логгер для тестов middleware
+func newMiddlewareLogger(t *testing.T) (*Logger, *MockWriter) {
+	t.Helper()
+
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+	return logger, mockWriter
+}
+
+// TestMiddleware тестирует логирование запросов
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		expectedCode  int
+		expectedLevel string
+		expectedSize  float64
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("hello"))
+			},
+			expectedCode:  http.StatusOK,
+			expectedLevel: "INFO",
+			expectedSize:  5,
+		},
+		{
+			name:          "empty response",
+			handler:       func(w http.ResponseWriter, r *http.Request) {},
+			expectedCode:  http.StatusOK,
+			expectedLevel: "INFO",
+		},
+		{
+			name: "client error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "not found", http.StatusNotFound)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedLevel: "WARN",
+			expectedSize:  10,
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedCode:  http.StatusBadGateway,
+			expectedLevel: "ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, mockWriter := newMiddlewareLogger(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/users?id=1", nil)
+			req.Header.Set("User-Agent", "test-agent")
+			rec := httptest.NewRecorder()
+			Middleware(logger, nil)(tt.handler).ServeHTTP(rec, req)
+
+			records := parseJSONLines(t, mockWriter.String())
+			require.Len(t, records, 1)
+			assert.Equal(t, "HTTP request", records[0]["msg"])
+			assert.Equal(t, tt.expectedLevel, records[0]["level"])
+			assert.Equal(t, "GET", records[0]["method"])
+			assert.Equal(t, "/api/users", records[0]["path"])
+			assert.Equal(t, "test-agent", records[0]["user_agent"])
+			assert.Equal(t, float64(tt.expectedCode), records[0]["status_code"])
+			assert.Equal(t, tt.expectedSize, records[0]["response_size"])
+			assert.Equal(t, rec.Header().Get(RequestIDHeader), records[0]["request_id"])
+			assert.Len(t, rec.Header().Get(RequestIDHeader), 32)
+		})
+	}
+}
+
+// TestMiddlewareRequestContext тестирует идентификатор запроса и логгер в контексте
+func TestMiddlewareRequestContext(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).InfoContext(r.Context(), "loading user")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	Middleware(logger, nil)(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, "req-123", rec.Header().Get(RequestIDHeader))
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 2)
+	assert.Equal(t, "loading user", records[0]["msg"])
+	assert.Equal(t, "req-123", records[0]["request_id"])
+	assert.Equal(t, "POST", records[0]["http_method"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", records[0]["trace_id"])
+	assert.NotEqual(t, "00f067aa0ba902b7", records[0]["span_id"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", records[1]["trace_id"])
+
+	// Некорректный идентификатор из заголовка заменяется новым
+	mockWriter.Reset()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "bad id\nforged")
+	rec = httptest.NewRecorder()
+	Middleware(logger, nil)(handler).ServeHTTP(rec, req)
+	assert.Len(t, rec.Header().Get(RequestIDHeader), 32)
+}
+
+// TestMiddlewareSkip тестирует пропуск запросов
+func TestMiddlewareSkip(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	handler := Middleware(logger, &MiddlewareConfig{
+		RequestIDHeader: "X-Correlation-ID",
+		SkipPaths:       []string{"/healthz"},
+		Skip: func(r *http.Request) bool {
+			return r.Method == http.MethodOptions
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodOptions, "/api", nil))
+	assert.Empty(t, mockWriter.String())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api", nil))
+	assert.NotEmpty(t, rec.Header().Get("X-Correlation-ID"))
+	assert.Len(t, parseJSONLines(t, mockWriter.String()), 1)
+}
+
+// hijackRecorder httptest.ResponseRecorder с поддержкой http.Hijacker
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+// Hijack реализует http.Hijacker
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	server, client := net.Pipe()
+	_ = client.Close()
+	return server, bufio.NewReadWriter(bufio.NewReader(server), bufio.NewWriter(server)), nil
+}
+
+// TestMiddlewareResponseWriterInterfaces тестирует передачу Flusher, Hijacker и Pusher
+func TestMiddlewareResponseWriterInterfaces(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	t.Run("flush", func(t *testing.T) {
+		mockWriter.Reset()
+		rec := httptest.NewRecorder()
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, http.NewResponseController(w).Flush())
+			_, _, err := w.(http.Hijacker).Hijack()
+			assert.ErrorIs(t, err, http.ErrNotSupported)
+			assert.ErrorIs(t, w.(http.Pusher).Push("/style.css", nil), http.ErrNotSupported)
+		})
+		Middleware(logger, nil)(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.True(t, rec.Flushed)
+		records := parseJSONLines(t, mockWriter.String())
+		require.Len(t, records, 1)
+		assert.Equal(t, float64(http.StatusOK), records[0]["status_code"])
+	})
+
+	t.Run("hijack", func(t *testing.T) {
+		mockWriter.Reset()
+		rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, _, err := http.NewResponseController(w).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+		})
+		Middleware(logger, nil)(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+
+		records := parseJSONLines(t, mockWriter.String())
+		require.Len(t, records, 1)
+		assert.Equal(t, float64(http.StatusSwitchingProtocols), records[0]["status_code"])
+	})
+}
@@ -301,7 +301,7 @@ func TestFatalWithMock(t *testing.T) {
 	require.Len(t, records, 1)
 
 	record := records[0]
-	assert.Equal(t, slog.LevelError, record.Level)
+	assert.Equal(t, slog.Level(LevelFatal), record.Level)
 	assert.Equal(t, "fatal message", record.Message)
 }
 
@@ -322,7 +322,7 @@ func TestPanicWithMock(t *testing.T) {
 	require.Len(t, records, 1)
 
 	record := records[0]
-	assert.Equal(t, slog.LevelError, record.Level)
+	assert.Equal(t, slog.Level(LevelPanic), record.Level)
 	assert.Equal(t, "panic message", record.Message)
 }
 
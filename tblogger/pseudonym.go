@@ -0,0 +1,98 @@
+package tblogger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// Имена полей группы, заменяющей псевдонимизированное значение
+const (
+	pseudonymKeyIDKey = "key_id"
+	pseudonymHashKey  = "hash"
+)
+
+// Ошибки настройки ключа псевдонимизации
+var (
+	errEmptyPseudonymKey   = errors.New("pseudonymize: empty key")
+	errEmptyPseudonymKeyID = errors.New("pseudonymize: empty key id")
+)
+
+// pseudonymKey ключ HMAC с идентификатором
+type pseudonymKey struct {
+	id  string
+	key []byte
+}
+
+// pseudonymizer заменяет значения отмеченных полей на HMAC-SHA256 от значения.
+// Одинаковые значения дают одинаковый хеш при одном ключе, поэтому записи остаются
+// связываемыми между сервисами без хранения исходного идентификатора.
+type pseudonymizer struct {
+	keys    map[string]struct{}
+	current atomic.Pointer[pseudonymKey]
+}
+
+// newPseudonymizer создает псевдонимизатор по настройкам (nil — псевдонимизация отключена)
+func newPseudonymizer(config *PseudonymizeConfig) (*pseudonymizer, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	p := &pseudonymizer{keys: make(map[string]struct{}, len(config.Keys))}
+	for _, key := range config.Keys {
+		p.keys[strings.ToLower(key)] = struct{}{}
+	}
+	if err := p.setKey(config.KeyID, config.Key); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// setKey заменяет ключ HMAC. Записи, сделанные после вызова, используют новый ключ.
+// Пустые ключ и идентификатор не принимаются: хеш без секрета восстанавливается
+// перебором для идентификаторов с небольшим числом значений.
+func (p *pseudonymizer) setKey(id string, key []byte) error {
+	if len(key) == 0 {
+		return errEmptyPseudonymKey
+	}
+	if id == "" {
+		return errEmptyPseudonymKeyID
+	}
+	p.current.Store(&pseudonymKey{id: id, key: append([]byte(nil), key...)})
+	return nil
+}
+
+// pseudonymize заменяет значение отмеченного поля группой {key_id, hash}
+func (p *pseudonymizer) pseudonymize(groups []string, a slog.Attr) slog.Attr {
+	if _, ok := p.keys[strings.ToLower(a.Key)]; !ok {
+		return a
+	}
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey || a.Key == slog.SourceKey) {
+		return a
+	}
+
+	key := p.current.Load()
+	mac := hmac.New(sha256.New, key.key)
+	mac.Write([]byte(a.Value.String()))
+	sum := mac.Sum(nil)
+
+	return slog.Group(a.Key,
+		slog.String(pseudonymKeyIDKey, key.id),
+		slog.String(pseudonymHashKey, hex.EncodeToString(sum[:16])),
+	)
+}
+
+// SetPseudonymKey заменяет ключ псевдонимизации (ротация ключа). Идентификатор
+// ключа выводится вместе с хешем, чтобы было видно, каким ключом он получен.
+// Пустые keyID и key возвращают ошибку, текущий ключ при этом не меняется.
+// Ничего не делает, если псевдонимизация не настроена в Config.Pseudonymize.
+func (l *Logger) SetPseudonymKey(keyID string, key []byte) error {
+	if l.pseudonymizer == nil {
+		return nil
+	}
+	return l.pseudonymizer.setKey(keyID, key)
+}
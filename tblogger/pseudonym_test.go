@@ -0,0 +1,138 @@
+package tblogger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectedPseudonym вычисляет ожидаемый хеш значения
+func expectedPseudonym(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// TestLoggerPseudonymize тестирует замену идентификаторов ключевым хешем
+func TestLoggerPseudonymize(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		Pseudonymize: &PseudonymizeConfig{
+			Keys:  []string{"user_id", "Email"},
+			KeyID: "k1",
+			Key:   []byte("secret-1"),
+		},
+	})
+	require.NoError(t, err)
+
+	logger.WithUser("42", "alice").Info("login")
+	logger.WithFields(map[string]interface{}{"user_id": 42}).WithGroup("profile").Info("updated", "email", "alice@example.com")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 2)
+
+	assert.Equal(t, map[string]interface{}{
+		"key_id": "k1",
+		"hash":   expectedPseudonym([]byte("secret-1"), "42"),
+	}, records[0]["user_id"])
+	assert.Equal(t, "alice", records[0]["username"])
+
+	// Одинаковые значения дают одинаковый хеш
+	assert.Equal(t, records[0]["user_id"], records[1]["user_id"])
+
+	profile := records[1]["profile"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"key_id": "k1",
+		"hash":   expectedPseudonym([]byte("secret-1"), "alice@example.com"),
+	}, profile["email"])
+	assert.NotContains(t, mockWriter.String(), "alice@example.com")
+}
+
+// TestLoggerSetPseudonymKey тестирует ротацию ключа псевдонимизации
+func TestLoggerSetPseudonymKey(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		Pseudonymize: &PseudonymizeConfig{
+			Keys:  []string{"user_id"},
+			KeyID: "k1",
+			Key:   []byte("secret-1"),
+		},
+	})
+	require.NoError(t, err)
+
+	// Ключ общий для производных логгеров
+	child := logger.With("request_id", "req-1")
+	require.NoError(t, logger.SetPseudonymKey("k2", []byte("secret-2")))
+
+	// Пустой ключ или идентификатор не заменяют текущий ключ
+	assert.ErrorIs(t, logger.SetPseudonymKey("k3", nil), errEmptyPseudonymKey)
+	assert.ErrorIs(t, logger.SetPseudonymKey("", []byte("secret-3")), errEmptyPseudonymKeyID)
+	child.Info("login", "user_id", "42")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, map[string]interface{}{
+		"key_id": "k2",
+		"hash":   expectedPseudonym([]byte("secret-2"), "42"),
+	}, records[0]["user_id"])
+}
+
+// TestSetPseudonymKeyDisabled тестирует вызов без настроенной псевдонимизации
+func TestSetPseudonymKeyDisabled(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, logger.SetPseudonymKey("k2", []byte("secret-2")))
+	logger.Info("login", "user_id", "42")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, "42", records[0]["user_id"])
+}
+
+// TestPseudonymizeConfigErrors тестирует ошибки New при пустом ключе или идентификаторе
+func TestPseudonymizeConfigErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *PseudonymizeConfig
+		expected error
+	}{
+		{
+			name:     "nil key",
+			config:   &PseudonymizeConfig{Keys: []string{"user_id"}, KeyID: "k1"},
+			expected: errEmptyPseudonymKey,
+		},
+		{
+			name:     "empty key",
+			config:   &PseudonymizeConfig{Keys: []string{"user_id"}, KeyID: "k1", Key: []byte{}},
+			expected: errEmptyPseudonymKey,
+		},
+		{
+			name:     "empty key id",
+			config:   &PseudonymizeConfig{Keys: []string{"user_id"}, Key: []byte("secret-1")},
+			expected: errEmptyPseudonymKeyID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&Config{Output: NewMockWriter(), Pseudonymize: tt.config})
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package tblogger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// PanicHandler получает значение паники и стек вызовов после того, как паника записана в лог
+type PanicHandler func(ctx context.Context, recovered interface{}, stack []byte)
+
+// Recovery возвращает HTTP middleware, перехватывающее панику обработчика. Паника
+// записывается на уровне ERROR со значением, стеком вызовов и полями запроса, а
+// клиенту возвращается 500, если ответ еще не начат. При использовании вместе с
+// Middleware Recovery подключается внутри него, чтобы запрос тоже попал в лог:
+// Middleware(logger, nil)(Recovery(logger)(handler)).
+func Recovery(logger *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw, ok := w.(*responseWriter)
+			if !ok {
+				rw = &responseWriter{ResponseWriter: w}
+			}
+
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				// Прерывание ответа по соглашению net/http не является ошибкой
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				// Логгер с полями запроса, если его сохранил Middleware
+				l, ok := loggerFromContext(r.Context())
+				if !ok {
+					l = logger.With("http_method", r.Method, "http_path", r.URL.Path)
+				}
+				l.logPanic(r.Context(), "Panic recovered in HTTP handler", recovered, debug.Stack())
+
+				if !rw.wroteHeader && !rw.hijacked {
+					http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// Go запускает fn в отдельной горутине. Паника в fn перехватывается и записывается
+// на уровне ERROR со значением и стеком вызовов вместо аварийного завершения процесса.
+// Если logger равен nil, используется логгер из контекста (см. FromContext).
+func Go(ctx context.Context, logger *Logger, fn func(ctx context.Context)) {
+	GoWithCallback(ctx, logger, fn, nil)
+}
+
+// GoWithCallback работает как Go и дополнительно передает перехваченную панику в onPanic
+func GoWithCallback(ctx context.Context, logger *Logger, fn func(ctx context.Context), onPanic PanicHandler) {
+	if logger == nil {
+		logger = FromContext(ctx)
+	}
+
+	go func() {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			stack := debug.Stack()
+			logger.logPanic(ctx, "Panic recovered in goroutine", recovered, stack)
+			if onPanic != nil {
+				onPanic(ctx, recovered, stack)
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
+
+// logPanic записывает перехваченную панику на уровне ERROR. Источником записи
+// считается функция, в которой произошла паника.
+func (l *Logger) logPanic(ctx context.Context, msg string, recovered interface{}, stack []byte) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.slogger.Enabled(ctx, slog.LevelError) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), slog.LevelError, msg, panicPC())
+	r.Add(
+		"panic", fmt.Sprint(recovered),
+		"stack", string(stack),
+	)
+	_ = l.slogger.Handler().Handle(ctx, r)
+}
+
+// panicPC возвращает адрес в функции, вызвавшей панику: первый кадр после
+// runtime.gopanic, не относящийся к runtime (например, runtime.panicIndex).
+// Вызывается из отложенной функции, перехватившей панику; 0, если кадр не найден.
+func panicPC() uintptr {
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+
+	inPanic := false
+	for _, pc := range pcs[:n] {
+		fn := runtime.FuncForPC(pc - 1)
+		if fn == nil {
+			continue
+		}
+		name := fn.Name()
+		if !inPanic {
+			inPanic = name == "runtime.gopanic"
+			continue
+		}
+		if !strings.HasPrefix(name, "runtime.") {
+			return pc
+		}
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package tblogger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecovery тестирует перехват паники в HTTP обработчике
+func TestRecovery(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	handler := Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.Equal(t, "Panic recovered in HTTP handler", records[0]["msg"])
+	assert.Equal(t, "boom", records[0]["panic"])
+	assert.Equal(t, "GET", records[0]["http_method"])
+	assert.Equal(t, "/api/users", records[0]["http_path"])
+	assert.Contains(t, records[0]["stack"], "TestRecovery")
+}
+
+// TestRecoveryWithMiddleware тестирует перехват паники внутри Middleware
+func TestRecoveryWithMiddleware(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	handler := Middleware(logger, nil)(Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("after header")
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/jobs", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// Ответ уже начат, статус не меняется
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 2)
+	assert.Equal(t, "after header", records[0]["panic"])
+	assert.Equal(t, "req-1", records[0]["request_id"])
+	assert.Equal(t, "HTTP request", records[1]["msg"])
+	assert.Equal(t, float64(http.StatusAccepted), records[1]["status_code"])
+}
+
+// TestRecoveryAbortHandler тестирует, что http.ErrAbortHandler не перехватывается
+func TestRecoveryAbortHandler(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	handler := Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Empty(t, mockWriter.String())
+}
+
+// TestGo тестирует перехват паники в горутине
+func TestGo(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	type panicReport struct {
+		recovered interface{}
+		stack     []byte
+	}
+	reports := make(chan panicReport, 1)
+
+	ctx := ContextWithFields(context.Background(), "job", "sync")
+	GoWithCallback(ctx, logger, func(ctx context.Context) {
+		panic("worker failed")
+	}, func(ctx context.Context, recovered interface{}, stack []byte) {
+		reports <- panicReport{recovered: recovered, stack: stack}
+	})
+
+	report := <-reports
+	assert.Equal(t, "worker failed", report.recovered)
+	assert.NotEmpty(t, report.stack)
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, "Panic recovered in goroutine", records[0]["msg"])
+	assert.Equal(t, "worker failed", records[0]["panic"])
+	assert.Equal(t, "sync", records[0]["job"])
+}
+
+// TestGoWithoutPanic тестирует обычное выполнение функции в горутине
+func TestGoWithoutPanic(t *testing.T) {
+	logger, mockWriter := newMiddlewareLogger(t)
+
+	done := make(chan struct{})
+	Go(WithLogger(context.Background(), logger), nil, func(ctx context.Context) {
+		FromContext(ctx).Info("working")
+		close(done)
+	})
+	<-done
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 1)
+	assert.Equal(t, "working", records[0]["msg"])
+}
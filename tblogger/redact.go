@@ -0,0 +1,217 @@
+package tblogger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// MaskStyle определяет способ маскирования чувствительных данных
+type MaskStyle string
+
+const (
+	MaskFull    MaskStyle = "full"    // значение заменяется на [REDACTED]
+	MaskPartial MaskStyle = "partial" // сохраняются последние 4 символа: ********1234
+	MaskHash    MaskStyle = "hash"    // значение заменяется на hmac:<16 hex-символов> (HMAC-SHA256 с RedactConfig.HashKey)
+)
+
+// errEmptyRedactHashKey ошибка настройки MaskHash без ключа
+var errEmptyRedactHashKey = errors.New("redact: MaskHash requires HashKey")
+
+// redactedValue замена значения при MaskFull
+const redactedValue = "[REDACTED]"
+
+// RedactPattern описывает шаблон поиска чувствительных данных в строковых значениях
+type RedactPattern struct {
+	// Имя шаблона
+	Name string
+
+	// Регулярное выражение для поиска
+	Regexp *regexp.Regexp
+
+	// Дополнительная проверка найденного значения (nil — маскировать любое совпадение)
+	Validate func(match string) bool
+}
+
+// Предопределенные шаблоны поиска чувствительных данных
+var (
+	PatternCardNumber = RedactPattern{
+		Name:     "card_number",
+		Regexp:   regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		Validate: luhnValid,
+	}
+	PatternJWT = RedactPattern{
+		Name:   "jwt",
+		Regexp: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	}
+	PatternEmail = RedactPattern{
+		Name:   "email",
+		Regexp: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	}
+	// Номер телефона с кодом страны (+7 916 1234567), кодом в скобках
+	// ((916) 123-45-67) или разделителем после кода (916-123-45-67). Подряд
+	// идущие цифры без этих признаков (идентификаторы, метки времени Unix)
+	// не считаются телефоном.
+	PatternPhone = RedactPattern{
+		Name: "phone",
+		Regexp: regexp.MustCompile(`\+\d{1,3}[\s.-]?\(?\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{2}[\s.-]?\d{2}\b` +
+			`|\(\d{3}\)[\s.-]?\d{3}[\s.-]?\d{2}[\s.-]?\d{2}\b` +
+			`|\b\d{3}[\s.-]\d{3}[\s.-]?\d{2}[\s.-]?\d{2}\b`),
+	}
+)
+
+// DefaultRedactKeys имена полей, маскируемых по умолчанию
+var DefaultRedactKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"api_key",
+	"authorization",
+	"cookie",
+	"card_number",
+	"cvv",
+}
+
+// DefaultRedactConfig возвращает настройки маскирования с полями и шаблонами по умолчанию
+func DefaultRedactConfig() *RedactConfig {
+	return &RedactConfig{
+		Keys:     append([]string(nil), DefaultRedactKeys...),
+		Patterns: []RedactPattern{PatternCardNumber, PatternJWT, PatternEmail, PatternPhone},
+		Mask:     MaskFull,
+	}
+}
+
+// redactor маскирует значения полей из списка и фрагменты строк, найденные шаблонами
+type redactor struct {
+	keys     map[string]struct{}
+	patterns []RedactPattern
+	mask     MaskStyle
+	hashKey  []byte
+}
+
+// validate проверяет настройки маскирования. Хеш без секрета для номеров карт
+// и телефонов восстанавливается перебором, поэтому MaskHash требует HashKey.
+func (c *RedactConfig) validate() error {
+	if c != nil && c.Mask == MaskHash && len(c.HashKey) == 0 {
+		return errEmptyRedactHashKey
+	}
+	return nil
+}
+
+// newRedactor создает маскировщик по настройкам (nil — маскирование отключено)
+func newRedactor(config *RedactConfig) *redactor {
+	if config == nil {
+		return nil
+	}
+
+	r := &redactor{
+		keys:     make(map[string]struct{}, len(config.Keys)),
+		patterns: config.Patterns,
+		mask:     config.Mask,
+		hashKey:  append([]byte(nil), config.HashKey...),
+	}
+	for _, key := range config.Keys {
+		r.keys[strings.ToLower(key)] = struct{}{}
+	}
+	return r
+}
+
+// redact маскирует атрибут. Значение маскируется целиком, если имя поля или
+// одной из объемлющих групп входит в список; иначе в строковых значениях
+// маскируются фрагменты, найденные шаблонами.
+func (r *redactor) redact(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.SourceKey) {
+		return a
+	}
+
+	builtin := len(groups) == 0 && a.Key == slog.MessageKey
+	if !builtin && (r.deniedKey(a.Key) || r.deniedGroup(groups)) {
+		return slog.String(a.Key, r.maskValue(a.Value.String()))
+	}
+
+	if a.Value.Kind() != slog.KindString || len(r.patterns) == 0 {
+		return a
+	}
+
+	value := a.Value.String()
+	scrubbed := value
+	for _, p := range r.patterns {
+		scrubbed = p.Regexp.ReplaceAllStringFunc(scrubbed, func(match string) string {
+			if p.Validate != nil && !p.Validate(match) {
+				return match
+			}
+			return r.maskValue(match)
+		})
+	}
+	if scrubbed == value {
+		return a
+	}
+	return slog.String(a.Key, scrubbed)
+}
+
+// deniedKey проверяет, входит ли имя поля в список маскируемых
+func (r *redactor) deniedKey(key string) bool {
+	_, ok := r.keys[strings.ToLower(key)]
+	return ok
+}
+
+// deniedGroup проверяет, входит ли одна из групп в список маскируемых
+func (r *redactor) deniedGroup(groups []string) bool {
+	for _, g := range groups {
+		if r.deniedKey(g) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskValue маскирует значение выбранным способом
+func (r *redactor) maskValue(value string) string {
+	switch r.mask {
+	case MaskPartial:
+		runes := []rune(value)
+		if len(runes) <= 8 {
+			return strings.Repeat("*", len(runes))
+		}
+		return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+	case MaskHash:
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(value))
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:8])
+	default:
+		return redactedValue
+	}
+}
+
+// luhnValid проверяет номер карты по алгоритму Луна, игнорируя пробелы и дефисы
+func luhnValid(number string) bool {
+	var sum, digits int
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+		double = !double
+	}
+	return digits >= 13 && sum%10 == 0
+}
@@ -0,0 +1,381 @@
+package tblogger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// megabyte используется для перевода Config.MaxFileSize в байты
+	megabyte = 1024 * 1024
+
+	// backupTimeFormat формат времени в именах архивных файлов
+	backupTimeFormat = "2006-01-02T15-04-05.000000000"
+
+	// compressSuffix расширение сжатых архивных файлов
+	compressSuffix = ".gz"
+)
+
+// currentTime возвращает текущее время (переопределяется в тестах)
+var currentTime = time.Now
+
+// rotateOptions содержит параметры ротации файла логов
+type rotateOptions struct {
+	maxSize    int64          // максимальный размер файла в байтах, 0 — без ротации по размеру
+	maxBackups int            // количество хранимых архивных файлов, 0 — хранить все
+	maxAge     time.Duration  // срок хранения архивных файлов, 0 — без ограничения
+	every      time.Duration  // период ротации по времени, 0 — без ротации по времени
+	location   *time.Location // временная зона для границ периода ротации
+	compress   bool           // сжимать ли архивные файлы gzip
+}
+
+// rotatingWriter пишет логи в файл и ротирует его по размеру и/или по времени.
+// Архивные файлы получают имя вида name-<время>.ext (name-<время>.ext.gz после сжатия).
+// Сжатие и удаление устаревших архивов выполняются в фоновой горутине.
+// Безопасен для одновременной записи из нескольких горутин.
+type rotatingWriter struct {
+	mu           sync.Mutex
+	filePath     string
+	opts         rotateOptions
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+	closed       bool
+
+	millCh   chan struct{}
+	millDone chan struct{}
+}
+
+// newRotatingWriter открывает файл логов и возвращает писателя с ротацией
+func newRotatingWriter(filePath string, opts rotateOptions) (*rotatingWriter, error) {
+	if opts.location == nil {
+		opts.location = time.Local
+	}
+
+	w := &rotatingWriter{
+		filePath: filePath,
+		opts:     opts,
+	}
+	if err := w.openFile(); err != nil {
+		return nil, err
+	}
+
+	// Обработка архивов, оставшихся от предыдущих запусков
+	if opts.compress || opts.maxAge > 0 {
+		w.mu.Lock()
+		w.triggerMill()
+		w.mu.Unlock()
+	}
+	return w, nil
+}
+
+// Write записывает данные в файл, выполняя ротацию при необходимости
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	if w.file == nil {
+		if err := w.openFile(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Sync сбрасывает содержимое файла на диск
+func (w *rotatingWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Reopen закрывает и заново открывает файл по исходному пути.
+// Используется после перемещения файла внешним logrotate.
+func (w *rotatingWriter) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return os.ErrClosed
+	}
+	if err := w.closeFile(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	return w.openFile()
+}
+
+// Close закрывает текущий файл и дожидается завершения фоновой обработки архивов.
+// Последующие записи возвращают os.ErrClosed.
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	if w.millCh != nil {
+		close(w.millCh)
+		<-w.millDone
+		w.millCh = nil
+	}
+
+	return w.closeFile()
+}
+
+// shouldRotate проверяет, нужна ли ротация перед записью n байт
+func (w *rotatingWriter) shouldRotate(n int64) bool {
+	if w.opts.maxSize > 0 && w.size > 0 && w.size+n > w.opts.maxSize {
+		return true
+	}
+	return w.opts.every > 0 && !currentTime().Before(w.nextRotation)
+}
+
+// openFile открывает файл логов в режиме дозаписи
+func (w *rotatingWriter) openFile() error {
+	file, err := os.OpenFile(w.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+
+	if w.opts.every > 0 {
+		// Непустой файл относится к периоду, в котором он последний раз изменялся
+		periodStart := currentTime()
+		if w.size > 0 {
+			periodStart = info.ModTime()
+		}
+		w.nextRotation = nextBoundary(periodStart, w.opts.every, w.opts.location)
+	}
+	return nil
+}
+
+// nextBoundary возвращает начало следующего периода длительностью every после t.
+// Границы выравниваются по полуночи во временной зоне loc.
+func nextBoundary(t time.Time, every time.Duration, loc *time.Location) time.Time {
+	_, offset := t.In(loc).Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(every).Add(every).Add(-shift)
+}
+
+// closeFile закрывает текущий файл, если он открыт
+func (w *rotatingWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// rotate переименовывает текущий файл в архивный и открывает новый
+func (w *rotatingWriter) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	if err := os.Rename(w.filePath, w.backupName(currentTime())); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := w.openFile(); err != nil {
+		return err
+	}
+
+	if w.opts.compress || w.opts.maxAge > 0 || w.opts.maxBackups > 0 {
+		w.triggerMill()
+	}
+	return nil
+}
+
+// triggerMill запускает фоновую обработку архивов. Вызывается под w.mu.
+func (w *rotatingWriter) triggerMill() {
+	if w.millCh == nil {
+		w.millCh = make(chan struct{}, 1)
+		w.millDone = make(chan struct{})
+		go w.millRun(w.millCh, w.millDone)
+	}
+
+	select {
+	case w.millCh <- struct{}{}:
+	default:
+	}
+}
+
+// millRun обрабатывает архивы по сигналам до закрытия канала
+func (w *rotatingWriter) millRun(signals <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	for range signals {
+		_ = w.compressBackups()
+		_ = w.removeOldBackups()
+	}
+}
+
+// backupName возвращает имя архивного файла для указанного времени
+func (w *rotatingWriter) backupName(t time.Time) string {
+	dir := filepath.Dir(w.filePath)
+	prefix, ext := w.backupPrefixAndExt()
+	return filepath.Join(dir, prefix+t.UTC().Format(backupTimeFormat)+ext)
+}
+
+// backupPrefixAndExt возвращает префикс и расширение архивных файлов
+func (w *rotatingWriter) backupPrefixAndExt() (string, string) {
+	name := filepath.Base(w.filePath)
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + "-", ext
+}
+
+// backupFile описывает архивный файл логов
+type backupFile struct {
+	path       string
+	timestamp  time.Time
+	compressed bool
+}
+
+// listBackups возвращает архивные файлы, отсортированные от новых к старым
+func (w *rotatingWriter) listBackups() ([]backupFile, error) {
+	dir := filepath.Dir(w.filePath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log directory: %w", err)
+	}
+
+	prefix, ext := w.backupPrefixAndExt()
+	var backups []backupFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		compressed := strings.HasSuffix(name, ext+compressSuffix)
+		if !strings.HasPrefix(name, prefix) || (!compressed && !strings.HasSuffix(name, ext)) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimPrefix(name, prefix), compressSuffix)
+		ts = strings.TrimSuffix(ts, ext)
+		t, err := time.Parse(backupTimeFormat, ts)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{
+			path:       filepath.Join(dir, name),
+			timestamp:  t,
+			compressed: compressed,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+	return backups, nil
+}
+
+// removeOldBackups удаляет архивные файлы сверх maxBackups и старше maxAge
+func (w *rotatingWriter) removeOldBackups() error {
+	if w.opts.maxBackups <= 0 && w.opts.maxAge <= 0 {
+		return nil
+	}
+
+	backups, err := w.listBackups()
+	if err != nil {
+		return err
+	}
+
+	var cutoff time.Time
+	if w.opts.maxAge > 0 {
+		cutoff = currentTime().Add(-w.opts.maxAge)
+	}
+	for i, backup := range backups {
+		expired := backup.timestamp.Before(cutoff)
+		if !expired && (w.opts.maxBackups <= 0 || i < w.opts.maxBackups) {
+			continue
+		}
+		if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old log file: %w", err)
+		}
+	}
+	return nil
+}
+
+// compressBackups сжимает несжатые архивные файлы
+func (w *rotatingWriter) compressBackups() error {
+	if !w.opts.compress {
+		return nil
+	}
+
+	backups, err := w.listBackups()
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups {
+		if backup.compressed {
+			continue
+		}
+		if err := compressFile(backup.path, backup.path+compressSuffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressFile сжимает файл src в dst и удаляет src
+func compressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open log file for compression: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create compressed log file: %w", err)
+	}
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("failed to close compressed log file: %w", err)
+	}
+
+	return os.Remove(src)
+}
@@ -0,0 +1,283 @@
+package tblogger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRotatingWriterRotatesBySize тестирует ротацию при превышении размера
+func TestRotatingWriterRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+
+	writer, err := newRotatingWriter(filePath, rotateOptions{maxSize: 100})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	line := []byte(strings.Repeat("a", 59) + "\n")
+	for i := 0; i < 3; i++ {
+		n, err := writer.Write(line)
+		require.NoError(t, err)
+		assert.Equal(t, len(line), n)
+	}
+
+	backups, err := writer.listBackups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, line, data)
+}
+
+// TestRotatingWriterMaxBackups тестирует ограничение количества архивных файлов
+func TestRotatingWriterMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+
+	writer, err := newRotatingWriter(filePath, rotateOptions{maxSize: 10, maxBackups: 2})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err := writer.Write([]byte("0123456789"))
+		require.NoError(t, err)
+	}
+	// Close дожидается фонового удаления лишних архивов
+	require.NoError(t, writer.Close())
+
+	backups, err := writer.listBackups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	assert.True(t, backups[0].timestamp.After(backups[1].timestamp))
+}
+
+// TestRotatingWriterAppendsExisting тестирует дозапись в существующий файл
+func TestRotatingWriterAppendsExisting(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(filePath, []byte("existing\n"), 0644))
+
+	writer, err := newRotatingWriter(filePath, rotateOptions{maxSize: 1000, maxBackups: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), writer.size)
+
+	_, err = writer.Write([]byte("new\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "existing\nnew\n", string(data))
+}
+
+// TestRotatingWriterConcurrent тестирует одновременную запись из нескольких горутин
+func TestRotatingWriterConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+
+	writer, err := newRotatingWriter(filePath, rotateOptions{maxSize: 1024})
+	require.NoError(t, err)
+
+	line := []byte(strings.Repeat("x", 31) + "\n")
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, err := writer.Write(line)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, writer.Close())
+
+	// Все строки должны быть записаны целиком
+	files, err := filepath.Glob(filepath.Join(dir, "app*.log"))
+	require.NoError(t, err)
+
+	var total int
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(data), 1024)
+		for _, l := range bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) {
+			assert.Len(t, l, 31)
+			total++
+		}
+	}
+	assert.Equal(t, 800, total)
+}
+
+// TestNewWithFileRotation тестирует ротацию файла, заданного в Config.FilePath
+func TestNewWithFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "logs", "service.log")
+
+	config := &Config{
+		Level:       LevelInfo,
+		Format:      FormatJSON,
+		FilePath:    filePath,
+		MaxFileSize: 1,
+		MaxFiles:    3,
+	}
+
+	logger, err := New(config)
+	require.NoError(t, err)
+
+	payload := strings.Repeat("p", 64*1024)
+	for i := 0; i < 80; i++ {
+		logger.Info("large message", "payload", payload)
+	}
+
+	// Close дожидается фонового удаления лишних архивов
+	require.NoError(t, logger.Close())
+
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(megabyte))
+
+	backups, err := filepath.Glob(filepath.Join(dir, "logs", "service-*.log"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 3)
+}
+
+// TestNextBoundary тестирует вычисление границы следующего периода ротации
+func TestNextBoundary(t *testing.T) {
+	moscowTZ := time.FixedZone("MSK", 3*60*60)
+	base := time.Date(2025, 3, 10, 14, 25, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		every    time.Duration
+		loc      *time.Location
+		expected time.Time
+	}{
+		{
+			name:     "hourly",
+			every:    RotateHourly,
+			loc:      time.UTC,
+			expected: time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily UTC",
+			every:    RotateDaily,
+			loc:      time.UTC,
+			expected: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily Moscow",
+			every:    RotateDaily,
+			loc:      moscowTZ,
+			expected: time.Date(2025, 3, 11, 0, 0, 0, 0, moscowTZ),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.expected.Equal(nextBoundary(base, tt.every, tt.loc)))
+		})
+	}
+}
+
+// TestRotatingWriterRotatesByTime тестирует ротацию по времени
+func TestRotatingWriterRotatesByTime(t *testing.T) {
+	originalTime := currentTime
+	defer func() {
+		currentTime = originalTime
+	}()
+
+	now := time.Date(2025, 3, 10, 14, 25, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+
+	writer, err := newRotatingWriter(filePath, rotateOptions{every: RotateHourly, location: time.UTC})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	now = now.Add(10 * time.Minute)
+	_, err = writer.Write([]byte("same hour\n"))
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Minute)
+	_, err = writer.Write([]byte("next hour\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	backups, err := writer.listBackups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	data, err := os.ReadFile(backups[0].path)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsame hour\n", string(data))
+
+	data, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "next hour\n", string(data))
+}
+
+// TestRotatingWriterCompress тестирует сжатие архивных файлов
+func TestRotatingWriterCompress(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+
+	writer, err := newRotatingWriter(filePath, rotateOptions{maxSize: 10, compress: true})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("abcdefghij"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	backups, err := writer.listBackups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.True(t, backups[0].compressed)
+	assert.True(t, strings.HasSuffix(backups[0].path, ".log.gz"))
+
+	file, err := os.Open(backups[0].path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(data))
+}
+
+// TestRotatingWriterMaxAge тестирует удаление архивов старше MaxAge
+func TestRotatingWriterMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+
+	writer := &rotatingWriter{filePath: filePath}
+	oldBackup := writer.backupName(time.Now().Add(-15 * 24 * time.Hour))
+	freshBackup := writer.backupName(time.Now().Add(-24 * time.Hour))
+	require.NoError(t, os.WriteFile(oldBackup, []byte("old"), 0644))
+	require.NoError(t, os.WriteFile(freshBackup+compressSuffix, []byte("fresh"), 0644))
+
+	writer, err := newRotatingWriter(filePath, rotateOptions{maxAge: 14 * 24 * time.Hour})
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	assert.NoFileExists(t, oldBackup)
+	assert.FileExists(t, freshBackup+compressSuffix)
+}
@@ -0,0 +1,197 @@
+package tblogger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSamplingInterval интервал сэмплирования по умолчанию
+	defaultSamplingInterval = time.Second
+
+	// defaultSamplingReportInterval период отчета о подавленных записях по умолчанию
+	defaultSamplingReportInterval = time.Minute
+)
+
+// samplingKey идентифицирует повторяющиеся записи
+type samplingKey struct {
+	level slog.Level
+	msg   string
+}
+
+// samplingCounter считает записи с одинаковым ключом в текущем интервале
+type samplingCounter struct {
+	windowStart time.Time
+	count       int
+	suppressed  uint64
+}
+
+// sampler ограничивает количество записей с одинаковыми сообщением и уровнем:
+// за каждый интервал пропускаются первые Initial записей, затем каждая Thereafter-я.
+// Количество подавленных записей периодически выводится отдельной записью.
+type sampler struct {
+	config   SamplingConfig
+	report   slog.Handler // обработчик для отчетов о подавленных записях
+	mu       sync.Mutex
+	counters map[samplingKey]*samplingCounter
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+// newSampler создает сэмплер и запускает периодические отчеты
+func newSampler(config SamplingConfig, report slog.Handler) *sampler {
+	if config.Interval <= 0 {
+		config.Interval = defaultSamplingInterval
+	}
+	if config.ReportInterval == 0 {
+		config.ReportInterval = defaultSamplingReportInterval
+	}
+
+	s := &sampler{
+		config:   config,
+		report:   report,
+		counters: make(map[samplingKey]*samplingCounter),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if config.ReportInterval > 0 {
+		go s.run()
+	} else {
+		close(s.done)
+	}
+	return s
+}
+
+// allow решает, нужно ли логировать запись
+func (s *sampler) allow(level slog.Level, msg string) bool {
+	if s.config.ExemptErrors && level >= slog.LevelError {
+		return true
+	}
+
+	now := currentTime()
+	key := samplingKey{level: level, msg: msg}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok {
+		counter = &samplingCounter{windowStart: now}
+		s.counters[key] = counter
+	}
+	if now.Sub(counter.windowStart) >= s.config.Interval {
+		counter.windowStart = now
+		counter.count = 0
+	}
+
+	counter.count++
+	if counter.count <= s.config.Initial {
+		return true
+	}
+	if s.config.Thereafter > 0 && (counter.count-s.config.Initial)%s.config.Thereafter == 0 {
+		return true
+	}
+
+	counter.suppressed++
+	return false
+}
+
+// run периодически выводит отчеты до вызова Close
+func (s *sampler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.ReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flushReport()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// flushReport выводит количество подавленных записей и сбрасывает счетчики.
+// Счетчики, не использовавшиеся дольше интервала, удаляются.
+func (s *sampler) flushReport() {
+	now := currentTime()
+
+	type suppressedRecord struct {
+		key   samplingKey
+		count uint64
+	}
+	var records []suppressedRecord
+
+	s.mu.Lock()
+	for key, counter := range s.counters {
+		if counter.suppressed > 0 {
+			records = append(records, suppressedRecord{key: key, count: counter.suppressed})
+			counter.suppressed = 0
+		}
+		if now.Sub(counter.windowStart) >= s.config.Interval {
+			delete(s.counters, key)
+		}
+	}
+	s.mu.Unlock()
+
+	ctx := context.Background()
+	if !s.report.Enabled(ctx, slog.LevelWarn) {
+		return
+	}
+	for _, rec := range records {
+		r := slog.NewRecord(now, slog.LevelWarn, "Log records suppressed by sampling", 0)
+		r.AddAttrs(
+			slog.String("sampled_msg", rec.key.msg),
+			slog.String("sampled_level", LogLevel(rec.key.level).String()),
+			slog.Uint64("suppressed", rec.count),
+		)
+		_ = s.report.Handle(ctx, r)
+	}
+}
+
+// Close останавливает периодические отчеты и выводит итоговый отчет
+func (s *sampler) Close() error {
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+		if s.config.ReportInterval > 0 {
+			s.flushReport()
+		}
+	})
+	return nil
+}
+
+// samplingHandler пропускает к следующему обработчику только записи, разрешенные сэмплером
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+// Enabled реализует slog.Handler
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle реализует slog.Handler
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.sampler.allow(r.Level, r.Message) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs реализует slog.Handler
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+// WithGroup реализует slog.Handler
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
@@ -0,0 +1,98 @@
+package tblogger
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSamplerAllow тестирует пропуск первых записей и каждой M-й записи за интервал
+func TestSamplerAllow(t *testing.T) {
+	originalTime := currentTime
+	defer func() {
+		currentTime = originalTime
+	}()
+
+	now := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+
+	s := newSampler(SamplingConfig{
+		Initial:        2,
+		Thereafter:     3,
+		Interval:       time.Second,
+		ReportInterval: -1,
+	}, NewMockHandler())
+	defer s.Close()
+
+	var allowed []int
+	for i := 1; i <= 10; i++ {
+		if s.allow(slog.LevelInfo, "query") {
+			allowed = append(allowed, i)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 5, 8}, allowed)
+
+	// Другие сообщение и уровень считаются отдельно
+	assert.True(t, s.allow(slog.LevelInfo, "other"))
+	assert.True(t, s.allow(slog.LevelWarn, "query"))
+
+	// В новом интервале счетчик сбрасывается
+	now = now.Add(time.Second)
+	assert.True(t, s.allow(slog.LevelInfo, "query"))
+}
+
+// TestSamplerExemptErrors тестирует исключение ошибок из сэмплирования
+func TestSamplerExemptErrors(t *testing.T) {
+	s := newSampler(SamplingConfig{
+		Initial:        1,
+		ExemptErrors:   true,
+		ReportInterval: -1,
+	}, NewMockHandler())
+	defer s.Close()
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, s.allow(slog.LevelError, "failure"))
+	}
+	assert.True(t, s.allow(slog.LevelWarn, "warning"))
+	assert.False(t, s.allow(slog.LevelWarn, "warning"))
+}
+
+// TestLoggerSampling тестирует сэмплирование логгера и отчет о подавленных записях
+func TestLoggerSampling(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:       LevelDebug,
+		Format:      FormatJSON,
+		Output:      mockWriter,
+		ServiceName: "test-service",
+		Sampling: &SamplingConfig{
+			Initial:    2,
+			Thereafter: 3,
+			Interval:   time.Hour,
+		},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		logger.LogDBQuery("SELECT 1", time.Millisecond, 1)
+	}
+	assert.Equal(t, 4, strings.Count(mockWriter.String(), "Database query"))
+
+	// Итоговый отчет выводится при закрытии логгера
+	mockWriter.Reset()
+	require.NoError(t, logger.Close())
+
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(mockWriter.String()), &report))
+	assert.Equal(t, "WARN", report["level"])
+	assert.Equal(t, "Log records suppressed by sampling", report["msg"])
+	assert.Equal(t, "Database query", report["sampled_msg"])
+	assert.Equal(t, "DEBUG", report["sampled_level"])
+	assert.Equal(t, float64(6), report["suppressed"])
+	assert.Equal(t, "test-service", report["service"])
+}
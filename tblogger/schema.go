@@ -0,0 +1,331 @@
+package tblogger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Schema определяет имена служебных полей записи для систем сбора логов
+type Schema string
+
+const (
+	// SchemaDefault — имена полей slog: time, level, msg, source, service, version, environment
+	SchemaDefault Schema = ""
+
+	// SchemaECS — Elastic Common Schema: @timestamp, log.level, message, log.origin,
+	// service.name, service.version, service.environment, trace.id, span.id
+	SchemaECS Schema = "ecs"
+
+	// SchemaGCP — Google Cloud Logging: severity, message,
+	// logging.googleapis.com/sourceLocation, logging.googleapis.com/trace,
+	// logging.googleapis.com/spanId, serviceContext
+	SchemaGCP Schema = "gcp"
+
+	// SchemaLogstash — формат Logstash JSON: @timestamp, @version, message
+	SchemaLogstash Schema = "logstash"
+)
+
+// schemaFieldPrefix префикс полей записи, имена которых совпадают со служебными полями схемы
+const schemaFieldPrefix = "fields."
+
+// ecsVersion версия Elastic Common Schema, выводимая в поле ecs.version
+const ecsVersion = "8.11.0"
+
+// schemaKeys имена служебных полей схемы
+type schemaKeys struct {
+	time    string
+	level   string
+	message string
+	source  string
+	traceID string
+	spanID  string
+}
+
+// schemas имена служебных полей для каждой схемы
+var schemas = map[Schema]schemaKeys{
+	SchemaDefault: {
+		time:    slog.TimeKey,
+		level:   slog.LevelKey,
+		message: slog.MessageKey,
+		source:  slog.SourceKey,
+		traceID: traceIDKey,
+		spanID:  spanIDKey,
+	},
+	SchemaECS: {
+		time:    "@timestamp",
+		level:   "log.level",
+		message: "message",
+		source:  "log.origin",
+		traceID: "trace.id",
+		spanID:  "span.id",
+	},
+	SchemaGCP: {
+		time:    slog.TimeKey,
+		level:   "severity",
+		message: "message",
+		source:  "logging.googleapis.com/sourceLocation",
+		traceID: "logging.googleapis.com/trace",
+		spanID:  "logging.googleapis.com/spanId",
+	},
+	SchemaLogstash: {
+		time:    "@timestamp",
+		level:   "level",
+		message: "message",
+		source:  slog.SourceKey,
+		traceID: traceIDKey,
+		spanID:  spanIDKey,
+	},
+}
+
+// validate проверяет, что схема известна
+func (s Schema) validate() error {
+	if _, ok := schemas[s]; !ok {
+		return fmt.Errorf("unknown log schema: %q", s)
+	}
+	return nil
+}
+
+// serviceFields возвращает поля сведений о сервисе, добавляемые ко всем записям
+func (s Schema) serviceFields(config *Config) []interface{} {
+	switch s {
+	case SchemaECS:
+		return []interface{}{
+			"service.name", config.ServiceName,
+			"service.version", config.ServiceVersion,
+			"service.environment", config.Environment,
+			"ecs.version", ecsVersion,
+		}
+	case SchemaGCP:
+		return []interface{}{
+			slog.Group("serviceContext",
+				slog.String("service", config.ServiceName),
+				slog.String("version", config.ServiceVersion),
+			),
+			"environment", config.Environment,
+		}
+	case SchemaLogstash:
+		return []interface{}{
+			"@version", "1",
+			"service", config.ServiceName,
+			"version", config.ServiceVersion,
+			"environment", config.Environment,
+		}
+	default:
+		return []interface{}{
+			"service", config.ServiceName,
+			"version", config.ServiceVersion,
+			"environment", config.Environment,
+		}
+	}
+}
+
+// builtinAttr переименовывает служебное поле верхнего уровня по схеме и
+// преобразует значения уровня, источника и идентификатора трассировки. Время,
+// уровень и источник переименовываются, только если значение имеет тип
+// встроенного поля slog.
+func (s Schema) builtinAttr(a slog.Attr, gcpProjectID string) slog.Attr {
+	keys := schemas[s]
+
+	switch a.Key {
+	case slog.TimeKey:
+		if a.Value.Kind() == slog.KindTime {
+			a.Key = keys.time
+		}
+	case slog.LevelKey:
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			return slog.String(keys.level, s.levelName(LogLevel(level)))
+		}
+	case slog.MessageKey:
+		a.Key = keys.message
+	case slog.SourceKey:
+		if source, ok := a.Value.Any().(*slog.Source); ok && source != nil {
+			return s.sourceAttr(keys.source, source)
+		}
+	case traceIDKey:
+		a.Key = keys.traceID
+		if s == SchemaGCP && gcpProjectID != "" {
+			a.Value = slog.StringValue(fmt.Sprintf("projects/%s/traces/%s", gcpProjectID, a.Value.String()))
+		}
+	case spanIDKey:
+		a.Key = keys.spanID
+	}
+	return a
+}
+
+// reservedKeys возвращает имена полей верхнего уровня, занятые схемой: ключи
+// встроенных полей slog, их имена в схеме и поля сведений о сервисе
+func (s Schema) reservedKeys() map[string]struct{} {
+	keys := schemas[s]
+	reserved := map[string]struct{}{}
+	for _, key := range []string{
+		slog.TimeKey, slog.LevelKey, slog.MessageKey, slog.SourceKey,
+		keys.time, keys.level, keys.message, keys.source, keys.traceID, keys.spanID,
+	} {
+		reserved[key] = struct{}{}
+	}
+
+	fields := s.serviceFields(&Config{})
+	for i := 0; i < len(fields); i++ {
+		switch f := fields[i].(type) {
+		case string:
+			reserved[f] = struct{}{}
+			i++
+		case slog.Attr:
+			reserved[f.Key] = struct{}{}
+		}
+	}
+
+	// Идентификаторы трассировки добавляет contextHandler
+	delete(reserved, traceIDKey)
+	delete(reserved, spanIDKey)
+	return reserved
+}
+
+// schemaHandler добавляет префикс schemaFieldPrefix к полям верхнего уровня,
+// имена которых заняты схемой, чтобы поле записи не дублировало служебное
+// (например, время или уровень) и не подменяло его при разборе
+type schemaHandler struct {
+	next     slog.Handler
+	reserved map[string]struct{}
+	grouped  bool // открыта группа: поля не попадают на верхний уровень
+}
+
+// newSchemaHandler создает обработчик полей с занятыми схемой именами
+func newSchemaHandler(next slog.Handler, schema Schema) *schemaHandler {
+	return &schemaHandler{next: next, reserved: schema.reservedKeys()}
+}
+
+// Enabled реализует slog.Handler
+func (h *schemaHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle реализует slog.Handler
+func (h *schemaHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.grouped {
+		return h.next.Handle(ctx, r)
+	}
+
+	renamed := false
+	r.Attrs(func(a slog.Attr) bool {
+		renamed = h.collides(a)
+		return !renamed
+	})
+	if !renamed {
+		return h.next.Handle(ctx, r)
+	}
+
+	r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		r2.AddAttrs(h.rename(a))
+		return true
+	})
+	return h.next.Handle(ctx, r2)
+}
+
+// WithAttrs реализует slog.Handler
+func (h *schemaHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if !h.grouped {
+		renamed := make([]slog.Attr, len(attrs))
+		for i, a := range attrs {
+			renamed[i] = h.rename(a)
+		}
+		attrs = renamed
+	}
+	return &schemaHandler{next: h.next.WithAttrs(attrs), reserved: h.reserved, grouped: h.grouped}
+}
+
+// WithGroup реализует slog.Handler
+func (h *schemaHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &schemaHandler{next: h.next.WithGroup(name), reserved: h.reserved, grouped: true}
+}
+
+// collides сообщает, занято ли схемой имя поля или одного из полей встроенной группы
+func (h *schemaHandler) collides(a slog.Attr) bool {
+	if a.Key == "" && a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			if h.collides(ga) {
+				return true
+			}
+		}
+		return false
+	}
+	_, ok := h.reserved[a.Key]
+	return ok
+}
+
+// rename добавляет префикс к полю с занятым схемой именем. Поля групп без
+// имени выводятся на верхнем уровне и проверяются так же.
+func (h *schemaHandler) rename(a slog.Attr) slog.Attr {
+	if a.Key == "" && a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		renamed := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			renamed[i] = h.rename(ga)
+		}
+		return slog.Attr{Value: slog.GroupValue(renamed...)}
+	}
+	if _, ok := h.reserved[a.Key]; ok {
+		a.Key = schemaFieldPrefix + a.Key
+	}
+	return a
+}
+
+// levelName возвращает имя уровня в схеме
+func (s Schema) levelName(level LogLevel) string {
+	switch s {
+	case SchemaECS:
+		return strings.ToLower(level.String())
+	case SchemaGCP:
+		return gcpSeverity(level)
+	default:
+		return level.String()
+	}
+}
+
+// sourceAttr возвращает место вызова в структуре, принятой в схеме
+func (s Schema) sourceAttr(key string, source *slog.Source) slog.Attr {
+	switch s {
+	case SchemaECS:
+		return slog.Group(key,
+			slog.Group("file",
+				slog.String("name", source.File),
+				slog.Int("line", source.Line),
+			),
+			slog.String("function", source.Function),
+		)
+	case SchemaGCP:
+		return slog.Group(key,
+			slog.String("file", source.File),
+			slog.Int("line", source.Line),
+			slog.String("function", source.Function),
+		)
+	default:
+		return slog.Any(key, source)
+	}
+}
+
+// gcpSeverity возвращает значение severity Google Cloud Logging для уровня
+func gcpSeverity(level LogLevel) string {
+	switch {
+	case level >= LevelFatal:
+		return "ALERT"
+	case level >= LevelPanic:
+		return "CRITICAL"
+	case level >= LevelError:
+		return "ERROR"
+	case level >= LevelWarn:
+		return "WARNING"
+	case level >= LevelNotice:
+		return "NOTICE"
+	case level >= LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
@@ -0,0 +1,235 @@
+package tblogger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logWithSchema записывает одну запись WARN с трассировкой в JSON по схеме
+func logWithSchema(t *testing.T, schema Schema, projectID string) (map[string]interface{}, SpanContext) {
+	t.Helper()
+
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:          LevelInfo,
+		Format:         FormatJSON,
+		Output:         mockWriter,
+		AddSource:      true,
+		ServiceName:    "billing",
+		ServiceVersion: "1.0.0",
+		Environment:    "production",
+		TimeZone:       time.UTC,
+		Schema:         schema,
+		GCPProjectID:   projectID,
+	})
+	require.NoError(t, err)
+
+	sc := NewSpanContext()
+	logger.WarnContext(ContextWithSpan(context.Background(), sc), "disk almost full", "free_mb", 12)
+
+	lines := parseJSONLines(t, mockWriter.String())
+	require.Len(t, lines, 1)
+	return lines[0], sc
+}
+
+// TestSchemaDefault тестирует имена полей по умолчанию
+func TestSchemaDefault(t *testing.T) {
+	entry, sc := logWithSchema(t, SchemaDefault, "")
+
+	assert.Contains(t, entry, "time")
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "disk almost full", entry["msg"])
+	assert.Equal(t, "billing", entry["service"])
+	assert.Equal(t, "1.0.0", entry["version"])
+	assert.Equal(t, "production", entry["environment"])
+	assert.Equal(t, sc.TraceID.String(), entry["trace_id"])
+	assert.Equal(t, sc.SpanID.String(), entry["span_id"])
+	assert.Equal(t, float64(12), entry["free_mb"])
+
+	source, ok := entry["source"].(map[string]interface{})
+	require.True(t, ok)
+	assert.True(t, strings.HasSuffix(source["file"].(string), "schema_test.go"))
+}
+
+// TestSchemaECS тестирует имена полей Elastic Common Schema
+func TestSchemaECS(t *testing.T) {
+	entry, sc := logWithSchema(t, SchemaECS, "")
+
+	_, err := time.Parse(time.RFC3339Nano, entry["@timestamp"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "warn", entry["log.level"])
+	assert.Equal(t, "disk almost full", entry["message"])
+	assert.Equal(t, "billing", entry["service.name"])
+	assert.Equal(t, "1.0.0", entry["service.version"])
+	assert.Equal(t, "production", entry["service.environment"])
+	assert.Equal(t, ecsVersion, entry["ecs.version"])
+	assert.Equal(t, sc.TraceID.String(), entry["trace.id"])
+	assert.Equal(t, sc.SpanID.String(), entry["span.id"])
+
+	origin, ok := entry["log.origin"].(map[string]interface{})
+	require.True(t, ok)
+	file := origin["file"].(map[string]interface{})
+	assert.True(t, strings.HasSuffix(file["name"].(string), "schema_test.go"))
+	assert.Greater(t, file["line"], float64(0))
+	assert.Contains(t, origin["function"], "logWithSchema")
+
+	for _, key := range []string{"time", "level", "msg", "source", "service", "version", "trace_id"} {
+		assert.NotContains(t, entry, key)
+	}
+}
+
+// TestSchemaGCP тестирует имена полей Google Cloud Logging
+func TestSchemaGCP(t *testing.T) {
+	entry, sc := logWithSchema(t, SchemaGCP, "my-project")
+
+	assert.Contains(t, entry, "time")
+	assert.Equal(t, "WARNING", entry["severity"])
+	assert.Equal(t, "disk almost full", entry["message"])
+	assert.Equal(t, "projects/my-project/traces/"+sc.TraceID.String(), entry["logging.googleapis.com/trace"])
+	assert.Equal(t, sc.SpanID.String(), entry["logging.googleapis.com/spanId"])
+	assert.Equal(t, map[string]interface{}{"service": "billing", "version": "1.0.0"}, entry["serviceContext"])
+	assert.Equal(t, "production", entry["environment"])
+
+	location, ok := entry["logging.googleapis.com/sourceLocation"].(map[string]interface{})
+	require.True(t, ok)
+	assert.True(t, strings.HasSuffix(location["file"].(string), "schema_test.go"))
+	assert.Greater(t, location["line"], float64(0))
+	assert.Contains(t, location["function"], "logWithSchema")
+
+	// Без проекта выводится идентификатор трассировки
+	entry, sc = logWithSchema(t, SchemaGCP, "")
+	assert.Equal(t, sc.TraceID.String(), entry["logging.googleapis.com/trace"])
+}
+
+// TestSchemaLogstash тестирует имена полей Logstash
+func TestSchemaLogstash(t *testing.T) {
+	entry, _ := logWithSchema(t, SchemaLogstash, "")
+
+	_, err := time.Parse(time.RFC3339Nano, entry["@timestamp"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", entry["@version"])
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "disk almost full", entry["message"])
+	assert.Equal(t, "billing", entry["service"])
+	assert.NotContains(t, entry, "time")
+	assert.NotContains(t, entry, "msg")
+}
+
+// TestSchemaGroups тестирует, что поля с именами служебных внутри групп не переименовываются
+func TestSchemaGroups(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{Level: LevelInfo, Output: mockWriter, Schema: SchemaECS})
+	require.NoError(t, err)
+
+	logger.WithGroup("job").Info("done", "msg", "inner", "level", 3)
+
+	lines := parseJSONLines(t, mockWriter.String())
+	require.Len(t, lines, 1)
+	assert.Equal(t, "done", lines[0]["message"])
+	assert.Equal(t, map[string]interface{}{"msg": "inner", "level": float64(3)}, lines[0]["job"])
+}
+
+// TestSchemaCollisions тестирует поля верхнего уровня с именами, занятыми схемой
+func TestSchemaCollisions(t *testing.T) {
+	userTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		schema Schema
+		fields []string // служебные поля схемы
+		keys   []string // имена, занятые схемой
+	}{
+		{
+			schema: SchemaECS,
+			fields: []string{"@timestamp", "log.level", "message", "service.name"},
+			keys:   []string{"time", "level", "msg", "source", "@timestamp", "log.level", "message", "service.name", "ecs.version"},
+		},
+		{
+			schema: SchemaGCP,
+			fields: []string{"time", "severity", "message", "serviceContext"},
+			keys:   []string{"time", "level", "msg", "source", "severity", "message", "serviceContext", "environment"},
+		},
+		{
+			schema: SchemaLogstash,
+			fields: []string{"@timestamp", "level", "message", "@version", "service"},
+			keys:   []string{"time", "level", "msg", "source", "@timestamp", "message", "@version", "service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.schema), func(t *testing.T) {
+			for _, key := range tt.keys {
+				// Строка и значение типа встроенного поля (время, уровень)
+				for _, value := range []interface{}{"user-value", userTime, slog.LevelError} {
+					mockWriter := NewMockWriter()
+					logger, err := New(&Config{Level: LevelInfo, Output: mockWriter, Schema: tt.schema, ServiceName: "billing"})
+					require.NoError(t, err)
+
+					logger.Info("m", key, value)
+					logger.With(key, value).Info("m")
+					logger.Info("m", slog.Group("", key, value))
+
+					for _, line := range strings.Split(strings.TrimSpace(mockWriter.String()), "\n") {
+						for _, field := range tt.fields {
+							assert.Equal(t, 1, strings.Count(line, `"`+field+`":`), "%s in %s", field, line)
+						}
+
+						entry := parseJSONLines(t, line)[0]
+						assert.Equal(t, "m", entry["message"])
+						assert.Contains(t, entry, schemaFieldPrefix+key, line)
+					}
+				}
+			}
+		})
+	}
+
+	// DefaultFields с занятыми схемой именами также выводятся с префиксом
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:         LevelInfo,
+		Output:        mockWriter,
+		Schema:        SchemaGCP,
+		DefaultFields: map[string]interface{}{"severity": "high"},
+	})
+	require.NoError(t, err)
+	logger.Info("m")
+
+	entry := parseJSONLines(t, mockWriter.String())[0]
+	assert.Equal(t, "INFO", entry["severity"])
+	assert.Equal(t, "high", entry["fields.severity"])
+}
+
+// TestGCPSeverity тестирует соответствие уровней значениям severity
+func TestGCPSeverity(t *testing.T) {
+	tests := []struct {
+		level    LogLevel
+		expected string
+	}{
+		{level: LevelTrace, expected: "DEBUG"},
+		{level: LevelDebug, expected: "DEBUG"},
+		{level: LevelInfo, expected: "INFO"},
+		{level: LevelNotice, expected: "NOTICE"},
+		{level: LevelWarn, expected: "WARNING"},
+		{level: LevelError, expected: "ERROR"},
+		{level: LevelError + 1, expected: "ERROR"},
+		{level: LevelPanic, expected: "CRITICAL"},
+		{level: LevelFatal, expected: "ALERT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, gcpSeverity(tt.level))
+		})
+	}
+}
+
+// TestUnknownSchema тестирует ошибку при неизвестной схеме
+func TestUnknownSchema(t *testing.T) {
+	_, err := New(&Config{Output: NewMockWriter(), Schema: "splunk"})
+	assert.EqualError(t, err, `unknown log schema: "splunk"`)
+}
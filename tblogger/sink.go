@@ -0,0 +1,146 @@
+package tblogger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// newSinkHandler создает обработчик формата для одного направления вывода.
+// Созданные выводы регистрируются в res для Flush/Close.
+func newSinkHandler(config *Config, sink Sink, replaceAttr func([]string, slog.Attr) slog.Attr, res *resources) (slog.Handler, error) {
+	// Формат проверяется до открытия файла
+	switch sink.Format {
+	case "", FormatJSON, FormatText, FormatConsole, FormatLogfmt:
+	default:
+		return nil, fmt.Errorf("unknown log format: %q", sink.Format)
+	}
+
+	// Настройка вывода
+	var output io.Writer = sink.Output
+	if sink.FilePath != "" {
+		file, err := setupFileOutput(sink.FilePath, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup file output: %w", err)
+		}
+		res.add(file)
+		output = file
+	}
+	if output == nil && len(config.Sinks) > 0 {
+		return nil, errors.New("sink has neither Output nor FilePath")
+	}
+
+	// Цвета консольного формата определяются по исходному выводу
+	color := sink.Format == FormatConsole && colorEnabled(output)
+
+	// Асинхронная запись через ограниченную очередь
+	if config.Async != nil && output != nil {
+		async := newAsyncWriter(output, config.Async)
+		res.add(async)
+		output = async
+	}
+
+	handlerOptions := &slog.HandlerOptions{
+		Level:       slog.Level(sink.Level),
+		AddSource:   sink.AddSource,
+		ReplaceAttr: replaceAttr,
+	}
+
+	// Создание обработчика в зависимости от формата
+	switch sink.Format {
+	case FormatText:
+		return slog.NewTextHandler(output, handlerOptions), nil
+	case FormatConsole:
+		return newConsoleHandler(output, handlerOptions, color), nil
+	case FormatLogfmt:
+		return newLogfmtHandler(output, handlerOptions), nil
+	default: // FormatJSON и пустой формат
+		return slog.NewJSONHandler(output, handlerOptions), nil
+	}
+}
+
+// newReplaceAttr возвращает функцию преобразования атрибутов, общую для всех выводов
+func newReplaceAttr(config *Config, pseudonymizer *pseudonymizer) func(groups []string, a slog.Attr) slog.Attr {
+	redactor := newRedactor(config.Redact)
+
+	return func(groups []string, a slog.Attr) slog.Attr {
+		// Ошибки в полях записи выводятся так же, как через WithError
+		if a.Value.Kind() == slog.KindAny {
+			if err, ok := a.Value.Any().(error); ok && err != nil {
+				a = errorAttr(a.Key, err)
+			}
+		}
+
+		// Псевдонимизация идентификаторов
+		if pseudonymizer != nil {
+			a = pseudonymizer.pseudonymize(groups, a)
+		}
+
+		// Маскирование чувствительных данных
+		if redactor != nil {
+			a = redactor.redact(groups, a)
+		}
+
+		if len(groups) > 0 {
+			return a
+		}
+
+		// Кастомизация атрибутов времени
+		if a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime && config.TimeZone != nil {
+			a.Value = slog.TimeValue(a.Value.Time().In(config.TimeZone))
+		}
+
+		// Имена служебных полей по схеме и имена уровней, которых нет в slog
+		// (TRACE, NOTICE, PANIC, FATAL)
+		return config.Schema.builtinAttr(a, config.GCPProjectID)
+	}
+}
+
+// multiHandler передает записи нескольким обработчикам, каждый со своим уровнем
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// Enabled реализует slog.Handler
+func (h *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle реализует slog.Handler
+func (h *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs реализует slog.Handler
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+// WithGroup реализует slog.Handler
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &multiHandler{handlers: handlers}
+}
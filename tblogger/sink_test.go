@@ -0,0 +1,98 @@
+package tblogger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSinksFanOut тестирует вывод в несколько направлений с разными уровнями и форматами
+func TestSinksFanOut(t *testing.T) {
+	dir := t.TempDir()
+	errorsPath := filepath.Join(dir, "errors.log")
+
+	textWriter := NewMockWriter()
+	jsonWriter := NewMockWriter()
+
+	logger, err := New(&Config{
+		Level: LevelDebug,
+		Sinks: []Sink{
+			{Level: LevelDebug, Format: FormatText, Output: textWriter},
+			{Level: LevelInfo, Format: FormatJSON, Output: jsonWriter},
+			{Level: LevelError, Format: FormatJSON, FilePath: errorsPath},
+		},
+		DefaultFields:  map[string]interface{}{"region": "eu"},
+		ServiceName:    "test-service",
+		ServiceVersion: "1.0.0",
+		Environment:    "test",
+	})
+	require.NoError(t, err)
+
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.With("order_id", 7).Error("error message")
+
+	text := textWriter.String()
+	assert.Contains(t, text, "level=DEBUG")
+	assert.Contains(t, text, "debug message")
+	assert.Contains(t, text, "info message")
+	assert.Contains(t, text, "error message")
+	assert.Contains(t, text, "service=test-service")
+
+	jsonLines := strings.Split(strings.TrimSpace(jsonWriter.String()), "\n")
+	require.Len(t, jsonLines, 2)
+	for _, line := range jsonLines {
+		var logData map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &logData))
+		assert.NotEqual(t, "debug message", logData["msg"])
+		assert.Equal(t, "eu", logData["region"])
+		assert.Equal(t, "test-service", logData["service"])
+	}
+
+	data, err := os.ReadFile(errorsPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &logData))
+	assert.Equal(t, "error message", logData["msg"])
+	assert.Equal(t, float64(7), logData["order_id"])
+	assert.Equal(t, "1.0.0", logData["version"])
+}
+
+// TestSinksGlobalLevel тестирует, что общий уровень логгера применяется до уровня вывода
+func TestSinksGlobalLevel(t *testing.T) {
+	writer := NewMockWriter()
+	logger, err := New(&Config{
+		Level: LevelInfo,
+		Sinks: []Sink{
+			{Level: LevelDebug, Format: FormatJSON, Output: writer},
+		},
+	})
+	require.NoError(t, err)
+
+	logger.Debug("hidden")
+	assert.Empty(t, writer.String())
+
+	logger.SetLevel(LevelDebug)
+	logger.WithGroup("request").Debug("visible", "id", 1)
+	assert.Contains(t, writer.String(), `"request":{"id":1}`)
+}
+
+// TestSinkWithoutOutput тестирует ошибку для вывода без Output и FilePath
+func TestSinkWithoutOutput(t *testing.T) {
+	logger, err := New(&Config{
+		Level: LevelInfo,
+		Sinks: []Sink{
+			{Level: LevelInfo, Format: FormatJSON},
+		},
+	})
+	assert.Error(t, err)
+	assert.Nil(t, logger)
+}
@@ -0,0 +1,237 @@
+package tblogger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Заголовки W3C Trace Context
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+// Имена полей трассировки в записях
+const (
+	traceIDKey = "trace_id"
+	spanIDKey  = "span_id"
+)
+
+// FlagSampled флаг traceparent, означающий, что трасса записывается
+const FlagSampled byte = 0x01
+
+// maxTraceStateMembers максимальное число элементов tracestate по спецификации
+const maxTraceStateMembers = 32
+
+// traceStateMember формат элемента tracestate: ключ (в том числе tenant@system) и значение
+var traceStateMember = regexp.MustCompile(
+	`^([a-z0-9][a-z0-9_*/-]{0,255}|[a-z0-9][a-z0-9_*/-]{0,240}@[a-z][a-z0-9_*/-]{0,13})=([\x20-\x2b\x2d-\x3c\x3e-\x7e]{0,255}[\x21-\x2b\x2d-\x3c\x3e-\x7e])$`,
+)
+
+// TraceID идентификатор трассы
+type TraceID [16]byte
+
+// IsValid сообщает, что идентификатор не нулевой
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// String возвращает идентификатор в виде 32 hex-символов
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID идентификатор операции (span)
+type SpanID [8]byte
+
+// IsValid сообщает, что идентификатор не нулевой
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// String возвращает идентификатор в виде 16 hex-символов
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext описывает контекст трассировки в формате W3C Trace Context
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	TraceFlags byte
+	TraceState string // нормализованный заголовок tracestate
+}
+
+// IsValid сообщает, что оба идентификатора не нулевые
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// IsSampled сообщает, что трасса записывается
+func (sc SpanContext) IsSampled() bool {
+	return sc.TraceFlags&FlagSampled != 0
+}
+
+// TraceParent возвращает значение заголовка traceparent
+func (sc SpanContext) TraceParent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, sc.TraceFlags)
+}
+
+// NewChild возвращает контекст дочерней операции той же трассы с новым SpanID
+func (sc SpanContext) NewChild() SpanContext {
+	child := sc
+	child.SpanID = newSpanID()
+	return child
+}
+
+// NewSpanContext создает контекст новой трассы со случайными идентификаторами
+func NewSpanContext() SpanContext {
+	var traceID TraceID
+	for !traceID.IsValid() {
+		_, _ = rand.Read(traceID[:])
+	}
+	return SpanContext{
+		TraceID:    traceID,
+		SpanID:     newSpanID(),
+		TraceFlags: FlagSampled,
+	}
+}
+
+// newSpanID создает случайный ненулевой SpanID
+func newSpanID() SpanID {
+	var spanID SpanID
+	for !spanID.IsValid() {
+		_, _ = rand.Read(spanID[:])
+	}
+	return spanID
+}
+
+// ParseTraceParent разбирает заголовок traceparent
+// (версия-trace_id-span_id-флаги, например 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01)
+func ParseTraceParent(header string) (SpanContext, error) {
+	header = strings.TrimSpace(header)
+
+	// Более новые версии могут добавлять поля после флагов
+	if len(header) < 55 || (len(header) > 55 && header[55] != '-') {
+		return SpanContext{}, fmt.Errorf("invalid traceparent: %q", header)
+	}
+	if header[2] != '-' || header[35] != '-' || header[52] != '-' {
+		return SpanContext{}, fmt.Errorf("invalid traceparent: %q", header)
+	}
+
+	version, err := decodeHexByte(header[0:2])
+	if err != nil || version == 0xff || (version == 0 && len(header) != 55) {
+		return SpanContext{}, fmt.Errorf("invalid traceparent version: %q", header)
+	}
+
+	var sc SpanContext
+	if err := decodeLowerHex(sc.TraceID[:], header[3:35]); err != nil || !sc.TraceID.IsValid() {
+		return SpanContext{}, fmt.Errorf("invalid traceparent trace id: %q", header)
+	}
+	if err := decodeLowerHex(sc.SpanID[:], header[36:52]); err != nil || !sc.SpanID.IsValid() {
+		return SpanContext{}, fmt.Errorf("invalid traceparent span id: %q", header)
+	}
+	if sc.TraceFlags, err = decodeHexByte(header[53:55]); err != nil {
+		return SpanContext{}, fmt.Errorf("invalid traceparent flags: %q", header)
+	}
+	return sc, nil
+}
+
+// ParseTraceState проверяет заголовок tracestate и возвращает его в нормализованном
+// виде: без пустых элементов и лишних пробелов
+func ParseTraceState(header string) (string, error) {
+	var members []string
+	seen := make(map[string]struct{})
+	for _, member := range strings.Split(header, ",") {
+		member = strings.Trim(member, " \t")
+		if member == "" {
+			continue
+		}
+
+		m := traceStateMember.FindStringSubmatch(member)
+		if m == nil {
+			return "", fmt.Errorf("invalid tracestate member: %q", member)
+		}
+		if _, ok := seen[m[1]]; ok {
+			return "", fmt.Errorf("duplicate tracestate key: %q", m[1])
+		}
+		seen[m[1]] = struct{}{}
+		members = append(members, member)
+	}
+	if len(members) > maxTraceStateMembers {
+		return "", errors.New("too many tracestate members")
+	}
+	return strings.Join(members, ","), nil
+}
+
+// ExtractTraceContext читает контекст трассировки из заголовков traceparent и tracestate.
+// Некорректный tracestate отбрасывается, не делая недействительным traceparent.
+func ExtractTraceContext(header http.Header) (SpanContext, error) {
+	sc, err := ParseTraceParent(header.Get(TraceParentHeader))
+	if err != nil {
+		return SpanContext{}, err
+	}
+	if state, err := ParseTraceState(strings.Join(header.Values(TraceStateHeader), ",")); err == nil {
+		sc.TraceState = state
+	}
+	return sc, nil
+}
+
+// InjectTraceContext записывает контекст трассировки в заголовки traceparent и tracestate
+func InjectTraceContext(header http.Header, sc SpanContext) {
+	if !sc.IsValid() {
+		return
+	}
+	header.Set(TraceParentHeader, sc.TraceParent())
+	if sc.TraceState != "" {
+		header.Set(TraceStateHeader, sc.TraceState)
+	} else {
+		header.Del(TraceStateHeader)
+	}
+}
+
+// spanKey ключ контекста трассировки в context.Context
+type spanKey struct{}
+
+// ContextWithSpan возвращает контекст с контекстом трассировки. Идентификаторы
+// трассы и операции добавляются к записям, сделанным методами *Context.
+func ContextWithSpan(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanKey{}, sc)
+}
+
+// SpanFromContext возвращает контекст трассировки, сохраненный через ContextWithSpan
+func SpanFromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	sc, ok := ctx.Value(spanKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// SpanSource извлекает контекст трассировки из context.Context. Позволяет подключить
+// внешнюю систему трассировки через Config.SpanSource.
+type SpanSource func(ctx context.Context) (SpanContext, bool)
+
+// decodeLowerHex декодирует hex-строку в нижнем регистре, как требует спецификация
+func decodeLowerHex(dst []byte, s string) error {
+	if strings.ToLower(s) != s {
+		return errors.New("uppercase hex")
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+// decodeHexByte декодирует два hex-символа в нижнем регистре
+func decodeHexByte(s string) (byte, error) {
+	var b [1]byte
+	if err := decodeLowerHex(b[:], s); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
@@ -0,0 +1,172 @@
+package tblogger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseTraceParent тестирует разбор заголовка traceparent
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+		{
+			name:   "future version with extra fields",
+			header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		},
+		{
+			name:    "version 00 with extra fields",
+			header:  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			wantErr: true,
+		},
+		{
+			name:    "forbidden version",
+			header:  "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantErr: true,
+		},
+		{
+			name:    "zero trace id",
+			header:  "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			wantErr: true,
+		},
+		{
+			name:    "zero span id",
+			header:  "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			wantErr: true,
+		},
+		{
+			name:    "uppercase hex",
+			header:  "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			wantErr: true,
+		},
+		{
+			name:    "too short",
+			header:  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			header:  "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := ParseTraceParent(tt.header)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID.String())
+			assert.Equal(t, "00f067aa0ba902b7", sc.SpanID.String())
+			assert.True(t, sc.IsSampled())
+		})
+	}
+}
+
+// TestParseTraceState тестирует разбор заголовка tracestate
+func TestParseTraceState(t *testing.T) {
+	state, err := ParseTraceState(" rojo=00f067aa0ba902b7 ,, congo=t61rcWkgMzE,tenant@vendor=x ")
+	require.NoError(t, err)
+	assert.Equal(t, "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE,tenant@vendor=x", state)
+
+	_, err = ParseTraceState("rojo=1,rojo=2")
+	assert.Error(t, err)
+
+	_, err = ParseTraceState("Rojo=1")
+	assert.Error(t, err)
+
+	_, err = ParseTraceState("rojo")
+	assert.Error(t, err)
+}
+
+// TestTraceContextHeaders тестирует генерацию и передачу контекста через заголовки
+func TestTraceContextHeaders(t *testing.T) {
+	sc := NewSpanContext()
+	require.True(t, sc.IsValid())
+	assert.True(t, sc.IsSampled())
+
+	child := sc.NewChild()
+	assert.Equal(t, sc.TraceID, child.TraceID)
+	assert.NotEqual(t, sc.SpanID, child.SpanID)
+
+	child.TraceState = "rojo=00f067aa0ba902b7"
+	header := http.Header{}
+	InjectTraceContext(header, child)
+
+	extracted, err := ExtractTraceContext(header)
+	require.NoError(t, err)
+	assert.Equal(t, child, extracted)
+
+	// Некорректный tracestate не делает недействительным traceparent
+	header.Set(TraceStateHeader, "INVALID")
+	extracted, err = ExtractTraceContext(header)
+	require.NoError(t, err)
+	assert.Equal(t, child.SpanID, extracted.SpanID)
+	assert.Empty(t, extracted.TraceState)
+
+	_, err = ExtractTraceContext(http.Header{})
+	assert.Error(t, err)
+}
+
+// TestLoggerTraceContext тестирует добавление идентификаторов трассировки в записи
+func TestLoggerTraceContext(t *testing.T) {
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+	})
+	require.NoError(t, err)
+
+	sc, err := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.NoError(t, err)
+	ctx := ContextWithSpan(context.Background(), sc)
+
+	logger.WithGroup("db").InfoContext(ctx, "query")
+	logger.Info("no context")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 2)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", records[0]["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", records[0]["span_id"])
+	assert.NotContains(t, records[1], "trace_id")
+}
+
+// TestLoggerSpanSource тестирует подключение внешнего источника трассировки
+func TestLoggerSpanSource(t *testing.T) {
+	type externalKey struct{}
+
+	mockWriter := NewMockWriter()
+	logger, err := New(&Config{
+		Level:  LevelInfo,
+		Format: FormatJSON,
+		Output: mockWriter,
+		SpanSource: func(ctx context.Context) (SpanContext, bool) {
+			sc, ok := ctx.Value(externalKey{}).(SpanContext)
+			return sc, ok
+		},
+	})
+	require.NoError(t, err)
+
+	sc := NewSpanContext()
+	logger.InfoContext(context.WithValue(context.Background(), externalKey{}, sc), "external")
+	logger.InfoContext(ContextWithSpan(context.Background(), NewSpanContext()), "ignored")
+
+	records := parseJSONLines(t, mockWriter.String())
+	require.Len(t, records, 2)
+	assert.Equal(t, sc.TraceID.String(), records[0]["trace_id"])
+	assert.NotContains(t, records[1], "trace_id")
+}